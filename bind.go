@@ -0,0 +1,212 @@
+package gofig
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+/*
+Struct tags read by Bind:
+
+	type Config struct {
+		Host  string        `gofig:"DATABASE_HOST" desc:"The database host" required:"true"`
+		Port  int           `gofig:"DATABASE_PORT" desc:"The database port" default:"5432"`
+		Hosts []string      `gofig:"REPLICA_HOSTS" desc:"Read replicas" sep:";"`
+		Wait  time.Duration `gofig:"CONNECT_TIMEOUT" desc:"How long to wait for the database" default:"5s"`
+	}
+
+Fields without a gofig tag, or with `gofig:"-"`, are left alone.
+*/
+const (
+	tagName     = "gofig"
+	tagDesc     = "desc"
+	tagRequired = "required"
+	tagDefault  = "default"
+	tagSep      = "sep"
+	tagNoTrim   = "notrim"
+	tagSecret   = "secret"
+	tagFromFile = "fromfile"
+)
+
+// the label used in errors about values in default tags
+const defaultTagLabel = "default tag"
+
+var ErrBindTargetNotStructPtr = errors.New("Bind must be passed a non-nil pointer to a struct")
+var ErrUnsupportedFieldType = func(field reflect.StructField) error {
+	return fmt.Errorf("field `%s` has type `%s`, which is not the Go type of any GfType", field.Name, field.Type)
+}
+var ErrUnexportedField = func(field reflect.StructField) error {
+	return fmt.Errorf("field `%s` has a `%s` tag but is not exported", field.Name, tagName)
+}
+var ErrInvalidStructTag = func(field reflect.StructField, tag string, reason error) error {
+	val := field.Tag.Get(tag)
+	// the default of a secret field is masked like the value it stands for
+	if secret, _ := strconv.ParseBool(field.Tag.Get(tagSecret)); secret && tag == tagDefault {
+		val = redacted
+	}
+	return fmt.Errorf("field `%s` has an invalid `%s` tag `%s`: %w", field.Name, tag, val, reason)
+}
+
+// boundField is a struct field with a gofig tag, along with the Id of its config option
+type boundField struct {
+	index []int
+	id    *Id
+}
+
+/*
+StructInitOpts returns the InitOpts described by the struct tags of the struct cfgPtr points to.
+Use it to get the documentation of a config struct with DocString.
+Bool default tags are parsed with DefaultBoolVocabulary.
+*/
+func StructInitOpts(cfgPtr any) ([]InitOpt, error) {
+	initOpts, _, err := structInitOpts(cfgPtr, DefaultBoolVocabulary)
+	return initOpts, err
+}
+
+/*
+Bind initializes the config options described by the struct tags of the struct cfgPtr points to,
+looking values up in the environment, and sets each tagged field to the value of its config option.
+The struct is only changed if every config option is fine.
+*/
+func Bind(cfgPtr any) error {
+	return BindWithSettings(cfgPtr, Settings{})
+}
+
+/*
+BindWithSettings is like Bind, but initializes the config options with InitWithSettings.
+Bool default tags are parsed with the BoolVocabulary of the settings.
+*/
+func BindWithSettings(cfgPtr any, settings Settings) error {
+	// default tags are written like values in the sources, so they use the same bool vocabulary
+	initOpts, fields, err := structInitOpts(cfgPtr, settings.BoolVocabulary.orDefault())
+	if err != nil {
+		return err
+	}
+
+	gf, err := InitWithSettings(initOpts, settings)
+	if err != nil {
+		return err
+	}
+
+	cfg := reflect.ValueOf(cfgPtr).Elem()
+	for _, field := range fields {
+		val, err := gf.get(*field.id)
+		if err != nil {
+			return err
+		}
+		cfg.FieldByIndex(field.index).Set(reflect.ValueOf(val))
+	}
+	return nil
+}
+
+// structInitOpts builds an InitOpt for each tagged field of the struct cfgPtr points to
+func structInitOpts(cfgPtr any, bools BoolVocabulary) ([]InitOpt, []boundField, error) {
+	ptr := reflect.ValueOf(cfgPtr)
+	if ptr.Kind() != reflect.Pointer || ptr.IsNil() || ptr.Elem().Kind() != reflect.Struct {
+		return nil, nil, ErrBindTargetNotStructPtr
+	}
+	structType := ptr.Elem().Type()
+
+	var initOpts []InitOpt
+	var fields []boundField
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		name, ok := field.Tag.Lookup(tagName)
+		if !ok || name == "-" {
+			continue
+		}
+		if !field.IsExported() {
+			return nil, nil, ErrUnexportedField(field)
+		}
+
+		initOpt, err := fieldInitOpt(field, name, bools)
+		if err != nil {
+			return nil, nil, err
+		}
+		initOpts = append(initOpts, initOpt)
+		fields = append(fields, boundField{index: field.Index, id: initOpt.IdPtr})
+	}
+
+	if len(initOpts) == 0 {
+		return nil, nil, ErrNoInputOpts
+	}
+	return initOpts, fields, nil
+}
+
+func fieldInitOpt(field reflect.StructField, name string, bools BoolVocabulary) (InitOpt, error) {
+	t, ok := gfTypeOf(field.Type)
+	if !ok {
+		return InitOpt{}, ErrUnsupportedFieldType(field)
+	}
+
+	initOpt := InitOpt{
+		Name:        name,
+		Description: field.Tag.Get(tagDesc),
+		Type:        t,
+		Separator:   field.Tag.Get(tagSep),
+		IdPtr:       &Id{},
+	}
+
+	var err error
+	if initOpt.Required, err = boolTag(field, tagRequired); err != nil {
+		return InitOpt{}, err
+	}
+	if initOpt.NoTrim, err = boolTag(field, tagNoTrim); err != nil {
+		return InitOpt{}, err
+	}
+	if initOpt.Secret, err = boolTag(field, tagSecret); err != nil {
+		return InitOpt{}, err
+	}
+	if initOpt.FromFile, err = boolTag(field, tagFromFile); err != nil {
+		return InitOpt{}, err
+	}
+
+	raw, hasDefault := field.Tag.Lookup(tagDefault)
+	switch {
+	case hasDefault:
+		// a default tag on a required field is left for Init to report
+		initOpt.Default, err = parseDefaultTag(initOpt, raw, bools)
+		if err != nil {
+			return InitOpt{}, ErrInvalidStructTag(field, tagDefault, err)
+		}
+	case !initOpt.Required:
+		initOpt.Default = zeroValue(t)
+	}
+	return initOpt, nil
+}
+
+func boolTag(field reflect.StructField, tag string) (bool, error) {
+	raw, ok := field.Tag.Lookup(tag)
+	if !ok {
+		return false, nil
+	}
+	val, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, ErrInvalidStructTag(field, tag, err)
+	}
+	return val, nil
+}
+
+// parseDefaultTag converts the default tag of a field the same way a value set as a string in a source is converted
+func parseDefaultTag(initOpt InitOpt, raw string, bools BoolVocabulary) (any, error) {
+	switch {
+	case initOpt.Type == TypeStringMap:
+		return parseStringMap(initOpt, raw, defaultTagLabel)
+	case isSliceType(initOpt.Type):
+		return convertList(initOpt, splitList(raw, separator(initOpt), !initOpt.NoTrim), defaultTagLabel, bools)
+	}
+	return convertRaw(initOpt, raw, defaultTagLabel, bools)
+}
+
+// gfTypeOf returns the GfType whose values are of the Go type passed in
+func gfTypeOf(goType reflect.Type) (GfType, bool) {
+	for t, typ := range goTypes {
+		if typ == goType {
+			return GfType(t), true
+		}
+	}
+	return 0, false
+}
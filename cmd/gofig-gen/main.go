@@ -0,0 +1,55 @@
+/*
+gofig-gen generates a typed config package from a schema file. See the gen package for the format of the schema.
+
+	gofig-gen -schema config.yaml -out config/config_gen.go
+
+It's handy in a go:generate directive next to the schema:
+
+	//go:generate go run github.com/ippontech/gofig/cmd/gofig-gen -schema schema.yaml -out config_gen.go
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/ippontech/gofig/gen"
+)
+
+func main() {
+	schemaPath := flag.String("schema", "", "The schema file to generate the config package from (.yaml, .yml or .json)")
+	outPath := flag.String("out", "", "The file to write the generated code to. Defaults to stdout")
+	pkg := flag.String("package", "", "The name of the generated package. Overrides the package in the schema")
+	flag.Parse()
+
+	if err := run(*schemaPath, *outPath, *pkg); err != nil {
+		fmt.Fprintf(os.Stderr, "gofig-gen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(schemaPath string, outPath string, pkg string) error {
+	if schemaPath == "" {
+		return fmt.Errorf("-schema is required")
+	}
+
+	schema, err := gen.ReadSchema(schemaPath)
+	if err != nil {
+		return err
+	}
+	if pkg != "" {
+		schema.Package = pkg
+	}
+
+	src, err := gen.Generate(schema, schemaPath)
+	if err != nil {
+		return err
+	}
+
+	if outPath == "" {
+		_, err = os.Stdout.Write(src)
+		return err
+	}
+	return os.WriteFile(outPath, src, 0644)
+}
@@ -0,0 +1,176 @@
+package gofig
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var ErrUnknownConfigInConstraint = func(rule string, name string) error {
+	return fmt.Errorf("constraint `%s` refers to config `%s`, which is not one of the initOpts", rule, name)
+}
+var ErrConstraintWithoutNames = func(rule string) error {
+	return fmt.Errorf("constraint `%s` refers to no config. use Requires, MutuallyExclusive, ExactlyOneOf or RequiredWhen to make constraints", rule)
+}
+var ErrConditionValueWrongType = func(rule string, when Condition, t GfType) error {
+	return fmt.Errorf("constraint `%s` compares config `%s` with `%v` of type `%T`. it must be of type `%s` to ever match",
+		rule, when.name, when.val, when.val, goTypes[t])
+}
+var ErrConstraintFailed = func(rule string, reason string) error {
+	return &ConstraintError{Rule: rule, Reason: reason}
+}
+
+type constraintKind int
+
+const (
+	constraintRequires constraintKind = iota
+	constraintMutuallyExclusive
+	constraintExactlyOneOf
+	constraintRequiredWhen
+)
+
+/*
+Constraint is a rule between config options, such as one config option requiring another.
+Constraints are about whether config options are set, meaning a source had a value for them. Defaults don't count.
+Pass them to InitWithSettings in Settings.Constraints.
+*/
+type Constraint struct {
+	kind  constraintKind
+	names []string
+	when  Condition // for RequiredWhen
+}
+
+/*
+Condition is a check on the value of a config option, used by RequiredWhen.
+Unlike constraints, conditions use the value of the config option even if it is the default.
+*/
+type Condition struct {
+	name  string
+	val   any
+	equal bool
+}
+
+// resolvedOpt is what Init knows about a config option when checking constraints
+type resolvedOpt struct {
+	t      GfType
+	set    bool // whether a source had a value for it
+	val    any
+	failed bool // whether Init found a problem with it. Constraints about it aren't checked.
+}
+
+// Requires makes setting the config option name require setting all the config options in required.
+func Requires(name string, required ...string) Constraint {
+	return Constraint{kind: constraintRequires, names: append([]string{name}, required...)}
+}
+
+// MutuallyExclusive allows at most one of the config options to be set.
+func MutuallyExclusive(names ...string) Constraint {
+	return Constraint{kind: constraintMutuallyExclusive, names: names}
+}
+
+// ExactlyOneOf requires exactly one of the config options to be set.
+func ExactlyOneOf(names ...string) Constraint {
+	return Constraint{kind: constraintExactlyOneOf, names: names}
+}
+
+// RequiredWhen makes the config option name required when the condition is true (e.g. RequiredWhen("DATABASE_PASSWORD", NotEquals("DATABASE_ENGINE", "sqlite"))).
+func RequiredWhen(name string, when Condition) Constraint {
+	return Constraint{kind: constraintRequiredWhen, names: []string{name}, when: when}
+}
+
+// Equals is true when the value of the config option name is val. val must be of the Go type of the config option (e.g. 2.0 for TypeFloat).
+func Equals(name string, val any) Condition {
+	return Condition{name: name, val: val, equal: true}
+}
+
+// NotEquals is true when the value of the config option name is not val.
+func NotEquals(name string, val any) Condition {
+	return Condition{name: name, val: val, equal: false}
+}
+
+func (c Condition) String() string {
+	op := "=="
+	if !c.equal {
+		op = "!="
+	}
+	return fmt.Sprintf("%s %s %v", c.name, op, c.val)
+}
+
+// Rule returns a short description of the constraint (e.g. "requires(A, B)"). Used in errors and docs.
+func (c Constraint) Rule() string {
+	switch c.kind {
+	case constraintRequires:
+		return fmt.Sprintf("requires(%s)", strings.Join(c.names, ", "))
+	case constraintMutuallyExclusive:
+		return fmt.Sprintf("mutuallyExclusive(%s)", strings.Join(c.names, ", "))
+	case constraintExactlyOneOf:
+		return fmt.Sprintf("exactlyOneOf(%s)", strings.Join(c.names, ", "))
+	case constraintRequiredWhen:
+		return fmt.Sprintf("requiredWhen(%s, %s)", strings.Join(c.names, ", "), c.when)
+	}
+	return "unknown"
+}
+
+// check returns an error if the constraint doesn't hold for the resolved config options
+func (c Constraint) check(resolved map[string]resolvedOpt) error {
+	if len(c.names) == 0 {
+		return ErrConstraintWithoutNames(c.Rule())
+	}
+	names := c.names
+	if c.kind == constraintRequiredWhen {
+		names = append([]string{c.when.name}, names...)
+	}
+	for _, name := range names {
+		if _, ok := resolved[name]; !ok {
+			return ErrUnknownConfigInConstraint(c.Rule(), name)
+		}
+	}
+	if c.kind == constraintRequiredWhen {
+		// like OneOf, the value is compared with == semantics, so a value of another type would never match
+		t := resolved[c.when.name].t
+		if t >= 0 && t < numTypes && reflect.TypeOf(c.when.val) != goTypes[t] {
+			return ErrConditionValueWrongType(c.Rule(), c.when, t)
+		}
+	}
+	for _, name := range names {
+		if resolved[name].failed {
+			return nil
+		}
+	}
+
+	var set []string
+	for _, name := range c.names {
+		if resolved[name].set {
+			set = append(set, name)
+		}
+	}
+
+	switch c.kind {
+	case constraintRequires:
+		if !resolved[c.names[0]].set {
+			return nil
+		}
+		for _, name := range c.names[1:] {
+			if !resolved[name].set {
+				return ErrConstraintFailed(c.Rule(), fmt.Sprintf("`%s` is set but `%s` is not", c.names[0], name))
+			}
+		}
+	case constraintMutuallyExclusive:
+		if len(set) > 1 {
+			return ErrConstraintFailed(c.Rule(), fmt.Sprintf("only one may be set, but `%s` are set", strings.Join(set, "`, `")))
+		}
+	case constraintExactlyOneOf:
+		if len(set) == 0 {
+			return ErrConstraintFailed(c.Rule(), "one must be set, but none are")
+		}
+		if len(set) > 1 {
+			return ErrConstraintFailed(c.Rule(), fmt.Sprintf("only one may be set, but `%s` are set", strings.Join(set, "`, `")))
+		}
+	case constraintRequiredWhen:
+		isEqual := reflect.DeepEqual(resolved[c.when.name].val, c.when.val)
+		if isEqual == c.when.equal && !resolved[c.names[0]].set {
+			return ErrConstraintFailed(c.Rule(), fmt.Sprintf("`%s` is required when %s", c.names[0], c.when))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,332 @@
+package gofig
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultSeparator = ","
+
+/*
+resolveValue looks the config option up in the sources and converts it to the type of the config option.
+origin is where the value came from. Its Kind is SourceDefault if no source had the config option.
+If no source has the config option and it can be read from a file, the file named by NAME_FILE is read instead.
+Values set as an empty string are handled according to the EmptyPolicy of the config option,
+and config options that aren't found get their default.
+*/
+func resolveValue(initOpt InitOpt, sources []Source, bools BoolVocabulary, files valueFiles) (val any, origin Origin, err error) {
+	var raw string
+	var elems []string
+	var pairs map[string]string
+	var structured bool // whether the source had a list or a map rather than a string
+	var found bool
+	var label string
+	var from Source
+
+	switch {
+	case initOpt.Type == TypeStringMap:
+		raw, pairs, structured, found, label, from = lookupStringMap(sources, initOpt.Name)
+		if structured {
+			raw = formatValue(initOpt, pairs)
+		}
+	case isSliceType(initOpt.Type):
+		raw, elems, structured, found, label, from = lookupList(sources, initOpt.Name)
+		if structured {
+			raw = joinList(initOpt, elems)
+		}
+	default:
+		raw, found, label, from = lookupSources(sources, initOpt.Name)
+	}
+	origin = Origin{Kind: sourceKind(from), Label: label, Raw: raw}
+
+	if !found && files.enabled(initOpt) {
+		raw, found, label, err = lookupValueFile(initOpt, sources, files)
+		origin = Origin{Kind: SourceFile, Label: label, Raw: raw}
+		if err != nil {
+			return nil, origin, err
+		}
+	}
+
+	if found && !structured && raw == "" {
+		switch emptyPolicy(initOpt) {
+		case EmptyAsUnset:
+			found = false
+		case EmptyAsZero:
+			return zeroValue(initOpt.Type), origin, nil
+		case EmptyIsError:
+			return nil, origin, ErrEmptyValueSet(initOpt, label)
+		}
+	}
+
+	if !found {
+		if initOpt.Required {
+			return nil, Origin{}, ErrRequiredConfigNotSet(initOpt.Name)
+		}
+		return copyValue(initOpt.Default), Origin{Kind: SourceDefault, Raw: formatValue(initOpt, initOpt.Default)}, nil
+	}
+
+	switch {
+	case initOpt.Type == TypeStringMap:
+		if structured {
+			return copyMap(pairs), origin, nil
+		}
+		val, err = parseStringMap(initOpt, raw, label)
+	case isSliceType(initOpt.Type):
+		if !structured {
+			elems = splitList(raw, separator(initOpt), !initOpt.NoTrim)
+		}
+		val, err = convertList(initOpt, elems, label, bools)
+	default:
+		val, err = convertRaw(initOpt, raw, label, bools)
+	}
+
+	if err != nil {
+		return nil, origin, err
+	}
+	return val, origin, nil
+}
+
+// emptyPolicy returns the EmptyPolicy of the config option, resolving EmptyDefault
+func emptyPolicy(initOpt InitOpt) EmptyPolicy {
+	if initOpt.Empty != EmptyDefault {
+		return initOpt.Empty
+	}
+	if initOpt.Type == TypeString || initOpt.Type == TypeStringMap || isSliceType(initOpt.Type) {
+		return EmptyAsZero
+	}
+	return EmptyAsUnset
+}
+
+// convertRaw converts the raw string value of a config option with a scalar type
+func convertRaw(initOpt InitOpt, raw string, label string, bools BoolVocabulary) (any, error) {
+	var val any
+	var err error
+	switch initOpt.Type {
+	case TypeBool:
+		val, err = bools.parse(raw)
+	case TypeInt:
+		val, err = strconv.Atoi(raw)
+	case TypeFloat:
+		val, err = strconv.ParseFloat(raw, 64)
+	case TypeString:
+		val = raw
+	case TypeDuration:
+		val, err = time.ParseDuration(raw)
+	case TypeTime:
+		val, err = time.Parse(time.RFC3339, raw)
+	}
+	if err != nil {
+		return nil, ErrWrongTypeSetInSource(initOpt, raw, label)
+	}
+	return val, nil
+}
+
+// convertList converts the elements of a config option with a slice type
+func convertList(initOpt InitOpt, elems []string, label string, bools BoolVocabulary) (any, error) {
+	switch initOpt.Type {
+	case TypeStringSlice:
+		return append([]string{}, elems...), nil
+	case TypeIntSlice:
+		return convertElems(initOpt, elems, label, strconv.Atoi)
+	case TypeFloatSlice:
+		return convertElems(initOpt, elems, label, func(elem string) (float64, error) {
+			return strconv.ParseFloat(elem, 64)
+		})
+	case TypeBoolSlice:
+		return convertElems(initOpt, elems, label, bools.parse)
+	}
+	return nil, ErrUnknownType(initOpt)
+}
+
+func convertElems[T any](initOpt InitOpt, elems []string, label string, parse func(string) (T, error)) ([]T, error) {
+	vals := make([]T, len(elems))
+	for i, elem := range elems {
+		val, err := parse(elem)
+		if err != nil {
+			return nil, ErrWrongTypeSetInSource(initOpt, elem, label)
+		}
+		vals[i] = val
+	}
+	return vals, nil
+}
+
+/*
+parseStringMap parses a raw string of key=value pairs separated by the separator (e.g. "a=1,b=2").
+The pairs are split like slices, then each pair is split on its first `=`.
+*/
+func parseStringMap(initOpt InitOpt, raw string, label string) (map[string]string, error) {
+	pairs := splitList(raw, separator(initOpt), !initOpt.NoTrim)
+	vals := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		key, val, found := strings.Cut(pair, "=")
+		if !found {
+			return nil, ErrWrongTypeSetInSource(initOpt, pair, label)
+		}
+		if !initOpt.NoTrim {
+			key, val = strings.TrimSpace(key), strings.TrimSpace(val)
+		}
+		if _, exists := vals[key]; exists {
+			return nil, ErrWrongTypeSetInSource(initOpt, pair, label)
+		}
+		vals[key] = val
+	}
+	return vals, nil
+}
+
+/*
+BoolVocabulary is the words that mean true and false for bool types. They are matched case-insensitively.
+Anything else is a conversion error.
+Bool flags of FlagSource passed without a value (e.g. `--debug`) are set as "true", so keep that word in a custom vocabulary if you use flags.
+*/
+type BoolVocabulary struct {
+	True  []string
+	False []string
+}
+
+/*
+DefaultBoolVocabulary is what strconv.ParseBool accepts, plus yes/no, y/n and on/off.
+*/
+var DefaultBoolVocabulary = BoolVocabulary{
+	True:  []string{"1", "t", "true", "y", "yes", "on"},
+	False: []string{"0", "f", "false", "n", "no", "off"},
+}
+
+var errNotBool = errors.New("not a bool")
+
+func (v BoolVocabulary) parse(raw string) (bool, error) {
+	for _, word := range v.True {
+		if strings.EqualFold(raw, word) {
+			return true, nil
+		}
+	}
+	for _, word := range v.False {
+		if strings.EqualFold(raw, word) {
+			return false, nil
+		}
+	}
+	return false, errNotBool
+}
+
+// orDefault returns DefaultBoolVocabulary if the vocabulary is empty
+func (v BoolVocabulary) orDefault() BoolVocabulary {
+	if len(v.True) == 0 && len(v.False) == 0 {
+		return DefaultBoolVocabulary
+	}
+	return v
+}
+
+func isSliceType(t GfType) bool {
+	return t == TypeStringSlice || t == TypeIntSlice || t == TypeFloatSlice || t == TypeBoolSlice
+}
+
+func separator(initOpt InitOpt) string {
+	if initOpt.Separator == "" {
+		return defaultSeparator
+	}
+	return initOpt.Separator
+}
+
+/*
+splitList splits a raw string into the elements of a slice.
+A backslash before the separator escapes it, and `\\` is a literal backslash.
+An empty string is an empty slice.
+*/
+func splitList(raw string, sep string, trim bool) []string {
+	elems := []string{}
+	if raw == "" {
+		return elems
+	}
+
+	var elem strings.Builder
+	for i := 0; i < len(raw); {
+		switch {
+		case raw[i] == '\\' && strings.HasPrefix(raw[i+1:], sep):
+			elem.WriteString(sep)
+			i += 1 + len(sep)
+		case raw[i] == '\\' && strings.HasPrefix(raw[i+1:], `\`):
+			elem.WriteByte('\\')
+			i += 2
+		case strings.HasPrefix(raw[i:], sep):
+			elems = append(elems, elem.String())
+			elem.Reset()
+			i += len(sep)
+		default:
+			elem.WriteByte(raw[i])
+			i++
+		}
+	}
+	elems = append(elems, elem.String())
+
+	if trim {
+		for i := range elems {
+			elems[i] = strings.TrimSpace(elems[i])
+		}
+	}
+	return elems
+}
+
+// formatValue formats a value of the config option the way it would be set as a string (e.g. slices are joined with the separator)
+func formatValue(initOpt InitOpt, val any) string {
+	switch v := val.(type) {
+	case []string:
+		return joinList(initOpt, v)
+	case []int:
+		return joinList(initOpt, v)
+	case []float64:
+		return joinList(initOpt, v)
+	case []bool:
+		return joinList(initOpt, v)
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case map[string]string:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		pairs := make([]string, len(keys))
+		for i, k := range keys {
+			pairs[i] = k + "=" + v[k]
+		}
+		return joinList(initOpt, pairs)
+	}
+	return fmt.Sprintf("%v", val)
+}
+
+func joinList[T any](initOpt InitOpt, vals []T) string {
+	sep := separator(initOpt)
+	elems := make([]string, len(vals))
+	for i, val := range vals {
+		elem := strings.ReplaceAll(fmt.Sprintf("%v", val), `\`, `\\`)
+		elems[i] = strings.ReplaceAll(elem, sep, `\`+sep)
+	}
+	return strings.Join(elems, sep)
+}
+
+// zeroValue returns the zero value of a GfType, with empty rather than nil slices and maps
+func zeroValue(t GfType) any {
+	switch goTypes[t].Kind() {
+	case reflect.Slice:
+		return reflect.MakeSlice(goTypes[t], 0, 0).Interface()
+	case reflect.Map:
+		return reflect.MakeMap(goTypes[t]).Interface()
+	}
+	return reflect.Zero(goTypes[t]).Interface()
+}
+
+// copyValue copies slices and maps, so a default can't be changed through the InitOpt it came from
+func copyValue(val any) any {
+	rv := reflect.ValueOf(val)
+	switch rv.Kind() {
+	case reflect.Slice:
+		return reflect.AppendSlice(reflect.MakeSlice(rv.Type(), 0, rv.Len()), rv).Interface()
+	case reflect.Map:
+		return copyMap(val.(map[string]string))
+	}
+	return val
+}
@@ -0,0 +1,283 @@
+package gofig
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+/*
+DocFormat is a layout for the documentation of config options returned by DocStringFormat.
+*/
+type DocFormat int
+
+const (
+	DocText     DocFormat = 0 // Tab-indented plain text. What DocString returns.
+	DocMarkdown DocFormat = 1 // A Markdown table, for READMEs
+	DocJSON     DocFormat = 2 // A JSON Schema of the config options, for tools
+	DocMan      DocFormat = 3 // A roff ENVIRONMENT section, for man pages
+)
+
+var ErrUnknownDocFormat = func(format DocFormat) error {
+	return fmt.Errorf("doc format `%d` is not a known DocFormat", format)
+}
+
+/*
+DocStringFormat returns the documentation for the config options passed in, in the format passed in.
+Every format has the name, description, type, whether the config option is required, its default, the rules of its validators and its allowed values.
+*/
+func DocStringFormat(initOpts []InitOpt, format DocFormat) (string, error) {
+	if len(initOpts) == 0 {
+		return "", ErrNoInputOpts
+	}
+	for _, initOpt := range initOpts {
+		if initOpt.Type < 0 || initOpt.Type >= numTypes {
+			return "", ErrUnknownType(initOpt)
+		}
+	}
+
+	switch format {
+	case DocText:
+		return docText(initOpts), nil
+	case DocMarkdown:
+		return docMarkdown(initOpts), nil
+	case DocJSON:
+		return docJSON(initOpts)
+	case DocMan:
+		return docMan(initOpts), nil
+	}
+	return "", ErrUnknownDocFormat(format)
+}
+
+func docText(initOpts []InitOpt) string {
+	var docs string
+
+	for _, initOpt := range initOpts {
+		docs += fmt.Sprintf(
+			"%s\n\tDescription: %s\n\tType: %s\n\tRequired: %v\n",
+			initOpt.Name,
+			initOpt.Description,
+			typeNames[initOpt.Type],
+			initOpt.Required,
+		)
+
+		if !initOpt.Required {
+			docs += fmt.Sprintf("\tDefault: %v\n", formatSecret(initOpt, initOpt.Default))
+		}
+		if initOpt.Secret {
+			docs += "\tSecret: true\n"
+		}
+		if rules := validatorRules(initOpt); len(rules) > 0 {
+			docs += fmt.Sprintf("\tValidators: %s\n", strings.Join(rules, ", "))
+		}
+	}
+
+	return docs
+}
+
+func docMarkdown(initOpts []InitOpt) string {
+	var b strings.Builder
+	b.WriteString("| Name | Type | Required | Default | Validators | Description |\n")
+	b.WriteString("|---|---|---|---|---|---|\n")
+
+	for _, initOpt := range initOpts {
+		var def string
+		if !initOpt.Required {
+			// an empty code span isn't rendered, so show the quotes of an empty default
+			def = "`" + formatSecret(initOpt, initOpt.Default) + "`"
+			if def == "``" {
+				def = `""`
+			}
+		}
+		rules := validatorRules(initOpt)
+		for i, rule := range rules {
+			rules[i] = "`" + rule + "`"
+		}
+		typeName := "`" + typeNames[initOpt.Type] + "`"
+		if initOpt.Secret {
+			typeName += " (secret)"
+		}
+		fmt.Fprintf(&b, "| `%s` | %s | %v | %s | %s | %s |\n",
+			initOpt.Name,
+			typeName,
+			initOpt.Required,
+			markdownCell(def),
+			markdownCell(strings.Join(rules, ", ")),
+			markdownCell(initOpt.Description),
+		)
+	}
+	return b.String()
+}
+
+// markdownCell escapes what would break a Markdown table cell
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.ReplaceAll(s, "\n", "<br>")
+}
+
+// jsonSchema is the JSON Schema of the config options, with the gofig specifics in x-gofig- keywords
+type jsonSchema struct {
+	Schema     string                        `json:"$schema"`
+	Type       string                        `json:"type"`
+	Properties map[string]jsonSchemaProperty `json:"properties"`
+	Required   []string                      `json:"required,omitempty"`
+}
+
+type jsonSchemaProperty struct {
+	Description string              `json:"description,omitempty"`
+	Type        string              `json:"type"`
+	Format      string              `json:"format,omitempty"`
+	Items       *jsonSchemaProperty `json:"items,omitempty"`
+	Additional  *jsonSchemaProperty `json:"additionalProperties,omitempty"`
+	Default     any                 `json:"default,omitempty"`
+	Enum        []any               `json:"enum,omitempty"`
+	WriteOnly   bool                `json:"writeOnly,omitempty"`
+	GofigType   string              `json:"x-gofig-type,omitempty"`
+	Secret      bool                `json:"x-gofig-secret,omitempty"`
+	Validators  []string            `json:"x-gofig-validators,omitempty"`
+}
+
+// the JSON Schema type of the values of each GfType
+var jsonSchemaTypes = [numTypes]jsonSchemaProperty{
+	{Type: "boolean"},
+	{Type: "integer"},
+	{Type: "number"},
+	{Type: "string"},
+	{Type: "array", Items: &jsonSchemaProperty{Type: "string"}},
+	{Type: "array", Items: &jsonSchemaProperty{Type: "integer"}},
+	{Type: "array", Items: &jsonSchemaProperty{Type: "number"}},
+	{Type: "array", Items: &jsonSchemaProperty{Type: "boolean"}},
+	{Type: "string", Format: "duration"},
+	{Type: "string", Format: "date-time"},
+	{Type: "object", Additional: &jsonSchemaProperty{Type: "string"}},
+}
+
+func docJSON(initOpts []InitOpt) (string, error) {
+	schema := jsonSchema{
+		Schema:     "https://json-schema.org/draft/2020-12/schema",
+		Type:       "object",
+		Properties: make(map[string]jsonSchemaProperty, len(initOpts)),
+	}
+
+	for _, initOpt := range initOpts {
+		prop := jsonSchemaTypes[initOpt.Type]
+		prop.Description = initOpt.Description
+		prop.GofigType = typeNames[initOpt.Type]
+		prop.Validators = validatorRules(initOpt)
+		// secrets are write-only and their default is left out
+		prop.Secret = initOpt.Secret
+		prop.WriteOnly = initOpt.Secret
+
+		if initOpt.Required {
+			schema.Required = append(schema.Required, initOpt.Name)
+		} else if !initOpt.Secret {
+			prop.Default = jsonSchemaValue(initOpt.Default)
+		}
+
+		// the allowed values of slices and maps are the allowed values of their elements
+		enum := &prop.Enum
+		switch {
+		case prop.Items != nil:
+			items := *prop.Items
+			prop.Items = &items
+			enum = &items.Enum
+		case prop.Additional != nil:
+			additional := *prop.Additional
+			prop.Additional = &additional
+			enum = &additional.Enum
+		}
+		for _, a := range allowedValues(initOpt) {
+			*enum = append(*enum, jsonSchemaValue(a))
+		}
+
+		schema.Properties[initOpt.Name] = prop
+	}
+
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data) + "\n", nil
+}
+
+// jsonSchemaValue returns a value the way it is written in the JSON Schema: durations and times are strings, like their format says
+func jsonSchemaValue(val any) any {
+	switch v := val.(type) {
+	case time.Duration:
+		return v.String()
+	case time.Time:
+		return v.Format(time.RFC3339)
+	}
+	return val
+}
+
+func docMan(initOpts []InitOpt) string {
+	var b strings.Builder
+	b.WriteString(".SH ENVIRONMENT\n")
+
+	for _, initOpt := range initOpts {
+		fmt.Fprintf(&b, ".TP\n.B %s\n", roffEscape(initOpt.Name))
+		if initOpt.Description != "" {
+			fmt.Fprintf(&b, "%s\n.br\n", roffEscape(initOpt.Description))
+		}
+
+		fmt.Fprintf(&b, "Type: %s.", roffEscape(typeNames[initOpt.Type]))
+		if initOpt.Secret {
+			b.WriteString(" Secret.")
+		}
+		if initOpt.Required {
+			b.WriteString(" Required.")
+		} else {
+			fmt.Fprintf(&b, " Default: %s.", roffEscape(formatSecret(initOpt, initOpt.Default)))
+		}
+		b.WriteString("\n")
+
+		if allowed := allowedValues(initOpt); len(allowed) > 0 {
+			strs := make([]string, len(allowed))
+			for i, a := range allowed {
+				strs[i] = fmt.Sprintf("%v", a)
+			}
+			fmt.Fprintf(&b, ".br\nAllowed values: %s.\n", roffEscape(strings.Join(strs, ", ")))
+		}
+		if rules := validatorRules(initOpt); len(rules) > 0 {
+			fmt.Fprintf(&b, ".br\nValidators: %s.\n", roffEscape(strings.Join(rules, ", ")))
+		}
+	}
+	return b.String()
+}
+
+// roffEscape escapes backslashes and dashes, and keeps lines starting with a control character from being read as requests
+func roffEscape(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\e`)
+	s = strings.ReplaceAll(s, "-", `\-`)
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, ".") || strings.HasPrefix(line, "'") {
+			lines[i] = `\&` + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// validatorRules returns the rules of the validators of the config option, skipping nil ones
+func validatorRules(initOpt InitOpt) []string {
+	var rules []string
+	for _, v := range initOpt.Validators {
+		if v != nil {
+			rules = append(rules, v.Rule())
+		}
+	}
+	return rules
+}
+
+// allowedValues returns the values allowed by the OneOf validators of the config option
+func allowedValues(initOpt InitOpt) []any {
+	var allowed []any
+	for _, v := range initOpt.Validators {
+		if v, ok := v.(validator); ok {
+			allowed = append(allowed, v.allowed...)
+		}
+	}
+	return allowed
+}
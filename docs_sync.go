@@ -0,0 +1,117 @@
+package gofig
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+)
+
+// The lines delimiting the block of a Markdown file that SyncDocs keeps up to date
+const (
+	DocsBeginMarker = "<!-- gofig:begin -->"
+	DocsEndMarker   = "<!-- gofig:end -->"
+)
+
+var ErrDocsMarkersNotFound = func(path string) error {
+	return fmt.Errorf("`%s` has no `%s` ... `%s` block to put the config docs in", path, DocsBeginMarker, DocsEndMarker)
+}
+var ErrDocsStale = func(path string) error {
+	return fmt.Errorf("config docs in `%s` are out of date. run `docs sync` to update them", path)
+}
+
+/*
+SyncDocs replaces the block between DocsBeginMarker and DocsEndMarker in the Markdown file at path
+with the DocMarkdown documentation of the config options passed in. The rest of the file is left as is.
+The file is only written if the docs changed.
+*/
+func SyncDocs(path string, initOpts []InitOpt) error {
+	current, synced, err := syncedDocs(path, initOpts)
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(current, synced) {
+		return nil
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, synced, info.Mode().Perm())
+}
+
+/*
+CheckDocs returns ErrDocsStale if SyncDocs would change the Markdown file at path. Handy in CI.
+*/
+func CheckDocs(path string, initOpts []InitOpt) error {
+	current, synced, err := syncedDocs(path, initOpts)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(current, synced) {
+		return ErrDocsStale(path)
+	}
+	return nil
+}
+
+/*
+RunDocsCommand runs `docs sync [--file README.md] [--check]` if args (typically os.Args[1:]) starts with it,
+and reports whether it did. --check runs CheckDocs instead of SyncDocs. Call it first thing in main:
+
+	if handled, err := gofig.RunDocsCommand(initOpts, os.Args[1:]); handled {
+		if err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+or from a go:generate directive:
+
+	//go:generate go run . docs sync --file ../README.md
+*/
+func RunDocsCommand(initOpts []InitOpt, args []string) (bool, error) {
+	if len(args) < 2 || args[0] != "docs" || args[1] != "sync" {
+		return false, nil
+	}
+
+	fs := flag.NewFlagSet("docs sync", flag.ContinueOnError)
+	path := fs.String("file", "README.md", "The Markdown file with the config docs block")
+	check := fs.Bool("check", false, "Fail if the config docs are out of date instead of updating them")
+	if err := fs.Parse(args[2:]); err != nil {
+		return true, err
+	}
+
+	if *check {
+		return true, CheckDocs(*path, initOpts)
+	}
+	return true, SyncDocs(*path, initOpts)
+}
+
+// syncedDocs returns the contents of the file at path, and what they would be with up to date docs
+func syncedDocs(path string, initOpts []InitOpt) (current []byte, synced []byte, err error) {
+	current, err = os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	docs, err := DocStringFormat(initOpts, DocMarkdown)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	begin := bytes.Index(current, []byte(DocsBeginMarker))
+	if begin < 0 {
+		return nil, nil, ErrDocsMarkersNotFound(path)
+	}
+	blockStart := begin + len(DocsBeginMarker)
+	end := bytes.Index(current[blockStart:], []byte(DocsEndMarker))
+	if end < 0 {
+		return nil, nil, ErrDocsMarkersNotFound(path)
+	}
+	end += blockStart
+
+	synced = append(synced, current[:blockStart]...)
+	synced = append(synced, "\n"+docs...)
+	synced = append(synced, current[end:]...)
+	return current, synced, nil
+}
@@ -0,0 +1,200 @@
+package gofig
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+var ErrParsingDotenvLine = func(path string, line int, reason string) error {
+	return ErrParsingConfigFile(path, fmt.Errorf("line %d: %s", line, reason))
+}
+
+/*
+NewDotenvSource reads and parses the dotenv file at the path passed in.
+The file is made of `NAME=value` lines, and supports:
+  - blank lines and comments starting with `#`, including comments at the end of unquoted values
+  - an `export ` prefix before the name
+  - single quoted values, which are used literally
+  - double quoted values, which can span several lines and support the escapes \n, \r, \t, \", \\ and \$
+  - expansion of `${OTHER}`, `$OTHER` and `${OTHER:-fallback}` in unquoted and double quoted values.
+    OTHER is looked up in the environment first and then in the lines above it in the file.
+  - names set more than once, where the last line wins like in docker compose and python-dotenv
+
+Put it after EnvSource in the chain of sources so the real environment takes precedence over the file.
+*/
+func NewDotenvSource(path string) (*FileSource, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, ErrReadingConfigFile(path, err)
+	}
+
+	fs := newFileSource(path)
+	vars := make(map[string]string)
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+
+	for i := 0; i < len(lines); i++ {
+		lineNum := i + 1
+		line := strings.TrimSpace(lines[i])
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if after, ok := strings.CutPrefix(line, "export"); ok && after != "" && isDotenvSpace(after[0]) {
+			line = strings.TrimSpace(after)
+		}
+
+		name, rest, found := strings.Cut(line, "=")
+		name = strings.TrimSpace(name)
+		if !found || name == "" || strings.ContainsAny(name, " \t") {
+			return nil, ErrParsingDotenvLine(path, lineNum, "expected NAME=value")
+		}
+		rest = strings.TrimLeft(rest, " \t")
+
+		var val string
+		switch {
+		case strings.HasPrefix(rest, "'"):
+			end := strings.Index(rest[1:], "'")
+			if end < 0 {
+				return nil, ErrParsingDotenvLine(path, lineNum, "unterminated single quoted value")
+			}
+			val = rest[1 : end+1]
+			if err := checkDotenvTrailer(rest[end+2:]); err != nil {
+				return nil, ErrParsingDotenvLine(path, lineNum, err.Error())
+			}
+		case strings.HasPrefix(rest, `"`):
+			// double quoted values can span several lines
+			quoted := rest[1:]
+			end := findClosingDoubleQuote(quoted)
+			for end < 0 && i+1 < len(lines) {
+				i++
+				quoted += "\n" + lines[i]
+				end = findClosingDoubleQuote(quoted)
+			}
+			if end < 0 {
+				return nil, ErrParsingDotenvLine(path, lineNum, "unterminated double quoted value")
+			}
+			if err := checkDotenvTrailer(quoted[end+1:]); err != nil {
+				return nil, ErrParsingDotenvLine(path, lineNum, err.Error())
+			}
+			val = expandDotenvValue(unescapeDotenvValue(quoted[:end]), vars)
+		default:
+			// a comment starts at a # after whitespace
+			for idx := 1; idx < len(rest); idx++ {
+				if rest[idx] == '#' && isDotenvSpace(rest[idx-1]) {
+					rest = rest[:idx]
+					break
+				}
+			}
+			val = expandDotenvValue(strings.TrimSpace(rest), vars)
+		}
+
+		vars[name] = val
+		fs.set(name, fileEntry{raw: val, line: lineNum})
+	}
+
+	return fs, nil
+}
+
+func isDotenvSpace(c byte) bool {
+	return c == ' ' || c == '\t'
+}
+
+// findClosingDoubleQuote returns the index of the first unescaped double quote, or -1
+func findClosingDoubleQuote(s string) int {
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
+
+// checkDotenvTrailer makes sure only whitespace or a comment follows a quoted value
+func checkDotenvTrailer(trailer string) error {
+	trailer = strings.TrimSpace(trailer)
+	if trailer != "" && !strings.HasPrefix(trailer, "#") {
+		return errors.New("unexpected characters after quoted value")
+	}
+	return nil
+}
+
+func unescapeDotenvValue(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 't':
+			b.WriteByte('\t')
+		case '$':
+			// keep the escape so expandDotenvValue leaves the dollar sign alone
+			b.WriteString(`\$`)
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+// expandDotenvValue replaces ${NAME}, $NAME and ${NAME:-fallback} with the value of NAME
+func expandDotenvValue(s string, vars map[string]string) string {
+	lookup := func(name string) (string, bool) {
+		if val, found := os.LookupEnv(name); found {
+			return val, true
+		}
+		val, found := vars[name]
+		return val, found
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && i+1 < len(s) && s[i+1] == '$':
+			b.WriteByte('$')
+			i++
+		case s[i] == '$' && i+1 < len(s) && s[i+1] == '{':
+			end := strings.IndexByte(s[i:], '}')
+			if end < 0 {
+				b.WriteString(s[i:])
+				return b.String()
+			}
+			name, fallback, hasFallback := strings.Cut(s[i+2:i+end], ":-")
+			if val, found := lookup(name); found && (val != "" || !hasFallback) {
+				b.WriteString(val)
+			} else {
+				b.WriteString(fallback)
+			}
+			i += end
+		case s[i] == '$':
+			end := i + 1
+			for end < len(s) && isDotenvNameChar(s[end]) {
+				end++
+			}
+			if end == i+1 {
+				b.WriteByte('$')
+				continue
+			}
+			val, _ := lookup(s[i+1 : end])
+			b.WriteString(val)
+			i = end - 1
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+func isDotenvNameChar(c byte) bool {
+	return c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
+}
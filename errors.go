@@ -0,0 +1,221 @@
+package gofig
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+/*
+Sentinel errors that the typed errors below wrap, for branching with errors.Is:
+
+	if errors.Is(err, gofig.ErrRequiredNotSet) { ... }
+*/
+var ErrRequiredNotSet = errors.New("required config option not set")
+var ErrConversion = errors.New("config value could not be converted to the type of the config option")
+var ErrEmptyValue = errors.New("config value is empty")
+var ErrInvalidDefault = errors.New("invalid default value")
+var ErrValidation = errors.New("config value failed validation")
+var ErrConstraint = errors.New("constraint between config options failed")
+var ErrValueFile = errors.New("config value could not be read from file")
+var ErrDuplicateName = errors.New("config name used by more than one initOpt")
+var ErrMissingIdPtr = errors.New("initOpt has no IdPtr")
+var ErrSharedIdPtr = errors.New("IdPtr used by more than one initOpt")
+
+/*
+RequiredNotSetError is returned when no source has a value for a required config option.
+It wraps ErrRequiredNotSet.
+*/
+type RequiredNotSetError struct {
+	Name string // The name of the config option
+}
+
+func (e *RequiredNotSetError) Error() string {
+	return fmt.Sprintf("required config option %s not set", e.Name)
+}
+
+func (e *RequiredNotSetError) Unwrap() error {
+	return ErrRequiredNotSet
+}
+
+/*
+ConversionError is returned when the value of a config option can't be converted to its type.
+It wraps ErrConversion.
+*/
+type ConversionError struct {
+	Name   string // The name of the config option
+	Type   GfType // The type of the config option
+	Raw    string // The value that couldn't be converted
+	Source string // The label of the source the value came from (e.g. "environment", "config.yaml:12")
+	Secret bool   // Whether the config option is a secret. Raw is masked in the message if it is.
+}
+
+func (e *ConversionError) Error() string {
+	typeName := typeNames[e.Type]
+	raw := e.Raw
+	if e.Secret {
+		raw = redacted
+	}
+	if e.Source == envSourceLabel {
+		return fmt.Sprintf("config `%s` of type `%s` was not set as `%s` in environment. environment value: `%s`", e.Name, typeName, typeName, raw)
+	}
+	return fmt.Sprintf("config `%s` of type `%s` was not set as `%s` in `%s`. value: `%s`", e.Name, typeName, typeName, e.Source, raw)
+}
+
+func (e *ConversionError) Unwrap() error {
+	return ErrConversion
+}
+
+/*
+EmptyValueError is returned when a config option with the EmptyIsError policy is set as an empty string.
+It wraps ErrEmptyValue.
+*/
+type EmptyValueError struct {
+	Name   string // The name of the config option
+	Source string // The label of the source that set it to an empty string
+}
+
+func (e *EmptyValueError) Error() string {
+	return fmt.Sprintf("config `%s` was set to an empty value in `%s`", e.Name, e.Source)
+}
+
+func (e *EmptyValueError) Unwrap() error {
+	return ErrEmptyValue
+}
+
+/*
+DefaultError is returned when the default value of a config option doesn't go with the rest of its InitOpt:
+it must be nil when the config option is required, and of the type of the config option otherwise.
+It wraps ErrInvalidDefault.
+*/
+type DefaultError struct {
+	Name     string // The name of the config option
+	Type     GfType // The type of the config option
+	Required bool   // Whether the config option is required
+	Default  any    // The default value
+	Secret   bool   // Whether the config option is a secret. Default is masked in the message if it is.
+}
+
+func (e *DefaultError) Error() string {
+	var def any = e.Default
+	if e.Secret {
+		def = redacted
+	}
+	switch {
+	case e.Required:
+		return fmt.Sprintf("config: `%v`. required: true. default value: `%v`. default value must be nil when config is required", e.Name, def)
+	case e.Default == nil:
+		return fmt.Sprintf("config: `%v`. required: false. default value: `nil`. default value must not be nil when config is not required", e.Name)
+	}
+	return fmt.Sprintf("config: `%v`. type: `%v`. default value of `%v` is not of type `%v`", e.Name, typeNames[e.Type], def, typeNames[e.Type])
+}
+
+func (e *DefaultError) Unwrap() error {
+	return ErrInvalidDefault
+}
+
+/*
+ValidationError is returned when the value of a config option doesn't follow one of its Validators.
+It wraps both ErrValidation and the error returned by the Validator.
+*/
+type ValidationError struct {
+	Name   string // The name of the config option
+	Rule   string // The rule of the Validator that failed (e.g. "min(1)")
+	Err    error  // The error returned by the Validator
+	Secret bool   // Whether the config option is a secret. Err is left out of the message if it is, since it may quote the value.
+}
+
+func (e *ValidationError) Error() string {
+	if e.Secret {
+		return fmt.Sprintf("config `%s` failed validation `%s`", e.Name, e.Rule)
+	}
+	return fmt.Sprintf("config `%s` failed validation `%s`: %v", e.Name, e.Rule, e.Err)
+}
+
+func (e *ValidationError) Unwrap() []error {
+	return []error{ErrValidation, e.Err}
+}
+
+/*
+ConstraintError is returned when a Constraint between config options doesn't hold.
+It wraps ErrConstraint.
+*/
+type ConstraintError struct {
+	Rule   string // The rule of the Constraint that failed (e.g. "requires(A, B)")
+	Reason string // Why it failed
+}
+
+func (e *ConstraintError) Error() string {
+	return fmt.Sprintf("constraint `%s` failed: %s", e.Rule, e.Reason)
+}
+
+func (e *ConstraintError) Unwrap() error {
+	return ErrConstraint
+}
+
+/*
+ValueFileError is returned when the file named by NAME_FILE can't be read for a config option with FromFile.
+It wraps both ErrValueFile and the reason (e.g. fs.ErrNotExist, ErrFileWorldWritable).
+*/
+type ValueFileError struct {
+	Name string // The name of the config option
+	Path string // The path of the file
+	Err  error  // Why the file couldn't be read
+}
+
+func (e *ValueFileError) Error() string {
+	return fmt.Sprintf("config `%s` could not be read from file `%s`: %v", e.Name, e.Path, e.Err)
+}
+
+func (e *ValueFileError) Unwrap() []error {
+	return []error{ErrValueFile, e.Err}
+}
+
+/*
+DuplicateNameError is returned when more than one InitOpt has the same Name.
+It wraps ErrDuplicateName.
+*/
+type DuplicateNameError struct {
+	Name    string // The name of the config options
+	Indexes []int  // The indexes of the InitOpts with the name
+}
+
+func (e *DuplicateNameError) Error() string {
+	return fmt.Sprintf("config `%s` is defined by more than one initOpt. initOpts: %s", e.Name, joinInts(e.Indexes))
+}
+
+func (e *DuplicateNameError) Unwrap() error {
+	return ErrDuplicateName
+}
+
+/*
+NilIdPtrError is returned when an InitOpt has a nil IdPtr.
+It wraps ErrMissingIdPtr.
+*/
+type NilIdPtrError struct {
+	Name string // The name of the config option
+}
+
+func (e *NilIdPtrError) Error() string {
+	return fmt.Sprintf("config `%s` has a nil IdPtr. it needs a pointer to an Id to store its Id in", e.Name)
+}
+
+func (e *NilIdPtrError) Unwrap() error {
+	return ErrMissingIdPtr
+}
+
+/*
+ReusedIdPtrError is returned when more than one InitOpt has the same IdPtr.
+It wraps ErrSharedIdPtr.
+*/
+type ReusedIdPtrError struct {
+	Names []string // The names of the config options sharing the IdPtr
+}
+
+func (e *ReusedIdPtrError) Error() string {
+	return fmt.Sprintf("configs `%s` have the same IdPtr. each config needs its own Id", strings.Join(e.Names, "`, `"))
+}
+
+func (e *ReusedIdPtrError) Unwrap() error {
+	return ErrSharedIdPtr
+}
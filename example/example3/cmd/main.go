@@ -1,13 +1,25 @@
 package main
 
 import (
+	"fmt"
 	"os"
 
+	"github.com/ippontech/gofig"
 	"github.com/ippontech/gofig/example/example3/config"
 	cdv "github.com/ippontech/gofig/example/example3/config_derived_values"
 )
 
+//go:generate go run . docs sync --file ../README.md
+
 func main() {
+	// `docs sync` updates the config docs in the README instead of running the app
+	if handled, err := gofig.RunDocsCommand(config.InitOpts(), os.Args[1:]); handled {
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
 
 	os.Setenv("DATABASE_ENGINE", "postgres")
 	os.Setenv("DATABASE_HOST", "localhost")
@@ -24,18 +24,16 @@ var GF gofig.Gofig
 // Init
 // Get-family functions
 
-// auto-add to readme
-// lookup id based on name given
-
-func Load() error {
-	var err error
-	initOpts := []gofig.InitOpt{
+// InitOpts returns the config options of the application. The README documents them with `go generate`.
+func InitOpts() []gofig.InitOpt {
+	return []gofig.InitOpt{
 		{
 			Name:        "DATABASE_ENGINE",
 			Description: "The database engine. Can be one of: postgres, mysql, sqlite",
 			Type:        gofig.TypeString,
 			Required:    true,
 			IdPtr:       &DatabaseEngineGfId,
+			Validators:  []gofig.Validator{gofig.OneOf("postgres", "mysql", "sqlite")},
 		},
 		{
 			Name:        "DATABASE_HOST",
@@ -61,9 +59,11 @@ func Load() error {
 		},
 		{
 			Name:        "DATABASE_PASSWORD",
-			Description: "The password for the database",
+			Description: "The password for the database. Required unless DATABASE_ENGINE is sqlite",
 			Type:        gofig.TypeString,
-			Required:    true,
+			Required:    false,
+			Default:     "",
+			Secret:      true,
 			IdPtr:       &DatabasePasswordGfId,
 		},
 		{
@@ -91,19 +91,29 @@ func Load() error {
 		},
 		{
 			Name:        "ENVIRONMENT",
-			Description: "The environment the application is running in",
+			Description: "The environment the application is running in. Can be one of: dev, uat, prod, local",
 			Type:        gofig.TypeString,
 			Required:    true,
 			IdPtr:       &EnvironmentGfId,
+			Validators:  []gofig.Validator{gofig.OneOf("dev", "uat", "prod", "local")},
 		},
 	}
+}
+
+func Load() error {
+	var err error
+	initOpts := InitOpts()
 	docStr, err := gofig.DocString(initOpts)
 	if err != nil {
 		return err
 	}
 	fmt.Println(docStr)
 
-	GF, err = gofig.Init(initOpts)
+	GF, err = gofig.InitWithSettings(initOpts, gofig.Settings{
+		Constraints: []gofig.Constraint{
+			gofig.RequiredWhen("DATABASE_PASSWORD", gofig.NotEquals("DATABASE_ENGINE", "sqlite")),
+		},
+	})
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ippontech/gofig/example/example5/config"
+)
+
+func main() {
+
+	os.Setenv("DATABASE_ENGINE", "postgres")
+	os.Setenv("DATABASE_HOST", "localhost")
+	os.Setenv("DATABASE_PORT", "6543")
+	os.Setenv("CONNECT_TIMEOUT", "10s")
+	os.Setenv("REPLICA_HOSTS", "replica1;replica2")
+	os.Setenv("ENABLE_AUDIT", "true")
+
+	err := config.Load()
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("connecting to %s at %s:%d (timeout %v, replicas %v, audit %v)\n",
+		config.DatabaseEngine(), config.DatabaseHost(), config.DatabasePort(),
+		config.ConnectTimeout(), config.ReplicaHosts(), config.EnableAudit())
+}
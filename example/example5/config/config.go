@@ -0,0 +1,3 @@
+package config
+
+//go:generate go run github.com/ippontech/gofig/cmd/gofig-gen -schema schema.yaml -out config_gen.go
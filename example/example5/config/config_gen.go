@@ -0,0 +1,128 @@
+// Code generated by gofig-gen from schema.yaml. DO NOT EDIT.
+
+package config
+
+import (
+	"time"
+
+	"github.com/ippontech/gofig"
+)
+
+var (
+	DatabaseEngineGfId gofig.Id
+	DatabaseHostGfId   gofig.Id
+	DatabasePortGfId   gofig.Id
+	ConnectTimeoutGfId gofig.Id
+	ReplicaHostsGfId   gofig.Id
+	EnableAuditGfId    gofig.Id
+)
+
+// GF holds the config values once Load has been called
+var GF gofig.Gofig
+
+// InitOpts returns the config options described by the schema
+func InitOpts() []gofig.InitOpt {
+	return []gofig.InitOpt{
+		{
+			Name:        "DATABASE_ENGINE",
+			Description: "The database engine. Can be one of: postgres, mysql, sqlite",
+			Type:        gofig.TypeString,
+			Required:    true,
+			IdPtr:       &DatabaseEngineGfId,
+		},
+		{
+			Name:        "DATABASE_HOST",
+			Description: "The database host",
+			Type:        gofig.TypeString,
+			Required:    true,
+			IdPtr:       &DatabaseHostGfId,
+		},
+		{
+			Name:        "DATABASE_PORT",
+			Description: "The database port",
+			Type:        gofig.TypeInt,
+			Required:    false,
+			Default:     5432,
+			IdPtr:       &DatabasePortGfId,
+		},
+		{
+			Name:        "CONNECT_TIMEOUT",
+			Description: "How long to wait for the database",
+			Type:        gofig.TypeDuration,
+			Required:    false,
+			Default:     5 * time.Second,
+			IdPtr:       &ConnectTimeoutGfId,
+		},
+		{
+			Name:        "REPLICA_HOSTS",
+			Description: "Hosts of the read replicas",
+			Type:        gofig.TypeStringSlice,
+			Required:    false,
+			Default:     []string{},
+			IdPtr:       &ReplicaHostsGfId,
+			Separator:   ";",
+		},
+		{
+			Name:        "ENABLE_AUDIT",
+			Description: "Enable audit logging",
+			Type:        gofig.TypeBool,
+			Required:    false,
+			Default:     false,
+			IdPtr:       &EnableAuditGfId,
+		},
+	}
+}
+
+// Load initializes GF from the environment
+func Load() error {
+	return LoadWithSettings(gofig.Settings{})
+}
+
+// LoadWithSettings initializes GF with gofig.InitWithSettings
+func LoadWithSettings(settings gofig.Settings) error {
+	var err error
+	GF, err = gofig.InitWithSettings(InitOpts(), settings)
+	return err
+}
+
+// DatabaseEngine returns the value of DATABASE_ENGINE, or its zero value if GF isn't loaded.
+// The database engine. Can be one of: postgres, mysql, sqlite
+func DatabaseEngine() string {
+	val, _ := GF.GetString(DatabaseEngineGfId)
+	return val
+}
+
+// DatabaseHost returns the value of DATABASE_HOST, or its zero value if GF isn't loaded.
+// The database host
+func DatabaseHost() string {
+	val, _ := GF.GetString(DatabaseHostGfId)
+	return val
+}
+
+// DatabasePort returns the value of DATABASE_PORT, or its zero value if GF isn't loaded.
+// The database port
+func DatabasePort() int {
+	val, _ := GF.GetInt(DatabasePortGfId)
+	return val
+}
+
+// ConnectTimeout returns the value of CONNECT_TIMEOUT, or its zero value if GF isn't loaded.
+// How long to wait for the database
+func ConnectTimeout() time.Duration {
+	val, _ := GF.GetDuration(ConnectTimeoutGfId)
+	return val
+}
+
+// ReplicaHosts returns the value of REPLICA_HOSTS, or its zero value if GF isn't loaded.
+// Hosts of the read replicas
+func ReplicaHosts() []string {
+	val, _ := GF.GetStringSlice(ReplicaHostsGfId)
+	return val
+}
+
+// EnableAudit returns the value of ENABLE_AUDIT, or its zero value if GF isn't loaded.
+// Enable audit logging
+func EnableAudit() bool {
+	val, _ := GF.GetBool(EnableAuditGfId)
+	return val
+}
@@ -0,0 +1,148 @@
+package gofig
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+var ErrReadingConfigFile = func(path string, err error) error {
+	return fmt.Errorf("could not read config file `%s`: %w", path, err)
+}
+var ErrParsingConfigFile = func(path string, err error) error {
+	return fmt.Errorf("could not parse config file `%s`: %w", path, err)
+}
+var ErrConfigFileNotMapping = func(path string) error {
+	return fmt.Errorf("config file `%s` must contain a mapping of config option names to values at the top level", path)
+}
+var ErrUnknownConfigFileFormat = func(path string) error {
+	return fmt.Errorf("config file `%s` has an unknown format. extension must be one of: .yaml, .yml, .json, .toml, .env", path)
+}
+var ErrDuplicateKeyInConfigFile = func(path string, key string, line int, prevLine int) error {
+	return fmt.Errorf("config file `%s`: key `%s` on line %d is a duplicate of the key on line %d", path, key, line, prevLine)
+}
+
+// fileEntry is a value found in a config file
+type fileEntry struct {
+	raw    string            // the value as a string, ready to be converted to the config option type by Init
+	line   int               // the line the value is on. 0 if the file format doesn't give us line numbers.
+	isList bool              // whether the value is a native list of scalars
+	elems  []string          // the elements of the value if it is a list
+	isMap  bool              // whether the value is a native map of scalars
+	pairs  map[string]string // the members of the value if it is a map
+}
+
+/*
+FileSource looks up config options in a config file.
+Keys in the file are matched to config option names by upper casing them and replacing `.` and `-` with `_`.
+Nested keys are joined with `_`, so all of these match the config option `DATABASE_HOST`:
+
+	DATABASE_HOST: localhost
+	database.host: localhost
+	database:
+	  host: localhost
+
+Lists of scalars (e.g. YAML sequences or JSON arrays) can be used for slice types,
+and maps of scalars (e.g. YAML mappings or JSON objects) for TypeStringMap.
+
+The label of a value is the path of the file and the line the value is on (e.g. "config.yaml:12"),
+or just the path of the file for formats that don't give us line numbers.
+*/
+type FileSource struct {
+	path    string
+	entries map[string]fileEntry // normalized key -> entry
+}
+
+/*
+NewFileSource reads and parses the config file at the path passed in.
+The format is detected from the extension of the file:
+  - .yaml, .yml: NewYamlSource
+  - .json: NewJsonSource
+  - .toml: NewTomlSource
+  - .env, or a name starting with ".env." (e.g. ".env.local"): NewDotenvSource
+*/
+func NewFileSource(path string) (*FileSource, error) {
+	if strings.HasPrefix(filepath.Base(path), ".env.") {
+		return NewDotenvSource(path)
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return NewYamlSource(path)
+	case ".json":
+		return NewJsonSource(path)
+	case ".toml":
+		return NewTomlSource(path)
+	case ".env":
+		return NewDotenvSource(path)
+	}
+	return nil, ErrUnknownConfigFileFormat(path)
+}
+
+// normalizeKey turns a key from a config file or a config option name into the form used to match them
+func normalizeKey(key string) string {
+	return strings.NewReplacer(".", "_", "-", "_").Replace(strings.ToUpper(key))
+}
+
+func newFileSource(path string) *FileSource {
+	return &FileSource{
+		path:    path,
+		entries: make(map[string]fileEntry),
+	}
+}
+
+func (fs *FileSource) add(keyPath []string, entry fileEntry) error {
+	key := normalizeKey(strings.Join(keyPath, "_"))
+	if prev, exists := fs.entries[key]; exists {
+		return ErrDuplicateKeyInConfigFile(fs.path, strings.Join(keyPath, "."), entry.line, prev.line)
+	}
+	fs.entries[key] = entry
+	return nil
+}
+
+// set adds the entry like add, but replaces the entry of a key that is already set instead of failing
+func (fs *FileSource) set(key string, entry fileEntry) {
+	fs.entries[normalizeKey(key)] = entry
+}
+
+func (fs *FileSource) Lookup(name string) (string, bool, string) {
+	entry, found := fs.entries[normalizeKey(name)]
+	if !found {
+		return "", false, ""
+	}
+	return entry.raw, true, fs.label(entry)
+}
+
+/*
+LookupList finds config options whose value in the file is a list of scalars (e.g. `[a, b]`),
+so slice types can use the elements directly instead of splitting the raw string.
+*/
+func (fs *FileSource) LookupList(name string) ([]string, bool, string) {
+	entry, found := fs.entries[normalizeKey(name)]
+	if !found || !entry.isList {
+		return nil, false, ""
+	}
+	return append([]string{}, entry.elems...), true, fs.label(entry)
+}
+
+/*
+LookupStringMap finds config options whose value in the file is a map of scalars (e.g. `{a: 1, b: 2}`),
+so TypeStringMap can use the members directly instead of parsing the raw string.
+*/
+func (fs *FileSource) LookupStringMap(name string) (map[string]string, bool, string) {
+	entry, found := fs.entries[normalizeKey(name)]
+	if !found || !entry.isMap {
+		return nil, false, ""
+	}
+	return copyMap(entry.pairs), true, fs.label(entry)
+}
+
+func (fs *FileSource) Kind() SourceKind {
+	return SourceFile
+}
+
+func (fs *FileSource) label(entry fileEntry) string {
+	if entry.line == 0 {
+		return fs.path
+	}
+	return fmt.Sprintf("%s:%d", fs.path, entry.line)
+}
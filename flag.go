@@ -0,0 +1,176 @@
+package gofig
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+var ErrDuplicateFlagName = func(flagName string, names ...string) error {
+	return fmt.Errorf("config options `%s` all map to flag `--%s`", strings.Join(names, "`, `"), flagName)
+}
+
+/*
+FlagSource looks up config options in command-line flags.
+One flag is registered per config option. The flag name is derived from the config option name with FlagName
+(e.g. "DATABASE_HOST" becomes "--database-host"), its type from the config option type
+and its usage text from the config option description.
+Only flags that were actually passed on the command line are found, so other sources later in the chain still apply.
+*/
+type FlagSource struct {
+	flagSet   *flag.FlagSet
+	flagNames map[string]string // config option name -> flag name
+	passed    map[string]bool   // flag names passed on the command line
+}
+
+/*
+FlagName returns the name of the flag for the config option with the given name.
+It lower cases the name and replaces underscores with dashes (e.g. "DATABASE_HOST" becomes "database-host").
+*/
+func FlagName(name string) string {
+	return strings.ReplaceAll(strings.ToLower(name), "_", "-")
+}
+
+/*
+NewFlagSource registers a flag for each of the config options passed in on a new flag.FlagSet.
+The FlagSet can be customized (e.g. to add flags that aren't config options) with FlagSet() before calling Parse.
+*/
+func NewFlagSource(initOpts []InitOpt) (*FlagSource, error) {
+	if len(initOpts) == 0 {
+		return nil, ErrNoInputOpts
+	}
+
+	fs := &FlagSource{
+		flagSet:   flag.NewFlagSet(os.Args[0], flag.ContinueOnError),
+		flagNames: make(map[string]string, len(initOpts)),
+		passed:    make(map[string]bool),
+	}
+
+	namesByFlag := make(map[string][]string, len(initOpts))
+	for _, initOpt := range initOpts {
+		namesByFlag[FlagName(initOpt.Name)] = append(namesByFlag[FlagName(initOpt.Name)], initOpt.Name)
+	}
+
+	for _, initOpt := range initOpts {
+		flagName := FlagName(initOpt.Name)
+		if names := namesByFlag[flagName]; len(names) > 1 {
+			return nil, ErrDuplicateFlagName(flagName, names...)
+		}
+		fs.flagNames[initOpt.Name] = flagName
+
+		usage := initOpt.Description
+		if initOpt.Required {
+			usage += " (required)"
+		}
+
+		// the flag default is only shown in the usage text. Flags that aren't passed are never found.
+		if initOpt.Secret {
+			initOpt.Default = nil
+		}
+		switch {
+		case initOpt.Type == TypeBool:
+			def := &rawFlag{isBool: true}
+			if d, _ := initOpt.Default.(bool); d {
+				def.raw = "true"
+			}
+			fs.flagSet.Var(def, flagName, usage)
+		case initOpt.Secret:
+			// the flag package would quote a bad value in its error. Init reports it masked instead.
+			fs.flagSet.Var(&rawFlag{}, flagName, usage)
+		case initOpt.Type == TypeInt:
+			def, _ := initOpt.Default.(int)
+			fs.flagSet.Int(flagName, def, usage)
+		case initOpt.Type == TypeFloat:
+			def, _ := initOpt.Default.(float64)
+			fs.flagSet.Float64(flagName, def, usage)
+		case initOpt.Type == TypeDuration:
+			def, _ := initOpt.Default.(time.Duration)
+			fs.flagSet.Duration(flagName, def, usage)
+		default:
+			var def string
+			if initOpt.Default != nil {
+				def = formatValue(initOpt, initOpt.Default)
+			}
+			fs.flagSet.String(flagName, def, usage)
+		}
+	}
+
+	return fs, nil
+}
+
+/*
+FlagSet returns the underlying flag.FlagSet.
+*/
+func (fs *FlagSource) FlagSet() *flag.FlagSet {
+	return fs.flagSet
+}
+
+/*
+Parse parses the command-line arguments passed in (usually os.Args[1:]).
+Nothing is found in the FlagSource until Parse has been called.
+*/
+func (fs *FlagSource) Parse(args []string) error {
+	if err := fs.flagSet.Parse(args); err != nil {
+		return err
+	}
+	fs.flagSet.Visit(func(f *flag.Flag) {
+		fs.passed[f.Name] = true
+	})
+	return nil
+}
+
+func (fs *FlagSource) Lookup(name string) (string, bool, string) {
+	flagName, ok := fs.flagNames[name]
+	if !ok || !fs.passed[flagName] {
+		return "", false, ""
+	}
+	return fs.flagSet.Lookup(flagName).Value.String(), true, "--" + flagName
+}
+
+func (fs *FlagSource) Kind() SourceKind {
+	return SourceFlag
+}
+
+/*
+rawFlag is a flag that keeps the raw string it was set to, so Init converts it like values from every other source.
+Bool flags use it so their values (e.g. `--debug=yes`) are parsed with the BoolVocabulary in use.
+A bool flag passed without a value (e.g. `--debug`) is set to "true".
+Secrets use it so a value that can't be converted is masked in the error.
+*/
+type rawFlag struct {
+	raw    string
+	isBool bool
+}
+
+func (b *rawFlag) String() string {
+	if b == nil {
+		return ""
+	}
+	return b.raw
+}
+
+func (b *rawFlag) Set(raw string) error {
+	b.raw = raw
+	return nil
+}
+
+func (b *rawFlag) IsBoolFlag() bool {
+	return b.isBool
+}
+
+/*
+InitWithFlags initializes the Gofig object with the config options passed in,
+looking up each value in the command-line flags (os.Args) first and in the environment second.
+*/
+func InitWithFlags(initOpts []InitOpt) (Gofig, error) {
+	fs, err := NewFlagSource(initOpts)
+	if err != nil {
+		return Gofig{}, err
+	}
+	if err := fs.Parse(os.Args[1:]); err != nil {
+		return Gofig{}, err
+	}
+	return InitWithSources(initOpts, fs, EnvSource{})
+}
@@ -0,0 +1,406 @@
+/*
+Package gen generates a typed config package from a schema file, so the schema is the single source of truth for the config options.
+
+The generated package has the []gofig.InitOpt of the config options, a gofig.Id for each of them,
+a Load function that initializes them and an accessor function for each value (e.g. DatabaseHost() string).
+
+A schema looks like this in YAML (JSON uses the same keys):
+
+	package: config
+	options:
+	  - name: DATABASE_HOST
+	    description: The database host
+	    type: string
+	    required: true
+	  - name: DATABASE_PORT
+	    description: The database port
+	    type: int
+	    default: "5432"
+
+Types are named like in gofig's docs: bool, int, float, string, []string, []int, []float, []bool, duration, time and map[string]string.
+Defaults are written the way they would be set in the environment (e.g. "a,b" for []string, "5s" for duration).
+*/
+package gen
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/ippontech/gofig"
+	"gopkg.in/yaml.v3"
+)
+
+/*
+Schema describes a config package.
+*/
+type Schema struct {
+	Package string   `yaml:"package" json:"package"` // The name of the generated package. Defaults to "config".
+	Options []Option `yaml:"options" json:"options"` // The config options, in the order they are documented in
+}
+
+/*
+Option describes one config option. The fields are the same as the ones of gofig.InitOpt.
+*/
+type Option struct {
+	Name        string `yaml:"name" json:"name"`
+	Description string `yaml:"description" json:"description"`
+	Type        string `yaml:"type" json:"type"`
+	Required    bool   `yaml:"required" json:"required"`
+	Default     string `yaml:"default" json:"default"` // The default value, written the way it would be set in the environment. Empty is the zero value of the type.
+	Separator   string `yaml:"separator" json:"separator"`
+	NoTrim      bool   `yaml:"noTrim" json:"noTrim"`
+	Secret      bool   `yaml:"secret" json:"secret"`
+	FromFile    bool   `yaml:"fromFile" json:"fromFile"`
+}
+
+/*
+**********************
+	+-----------------+
+	|Error Definitions|
+	+-----------------+
+**********************
+*/
+
+var ErrNoOptions = errors.New("schema has no options")
+var ErrUnknownSchemaFormat = func(path string) error {
+	return fmt.Errorf("schema `%s` has an unknown format. use .yaml, .yml or .json", path)
+}
+var ErrReadingSchema = func(path string, err error) error {
+	return fmt.Errorf("reading schema `%s`: %w", path, err)
+}
+var ErrUnknownOptionType = func(opt Option) error {
+	return fmt.Errorf("option `%s` has unknown type `%s`", opt.Name, opt.Type)
+}
+var ErrInvalidOptionName = func(opt Option) error {
+	return fmt.Errorf("option `%s` can't be turned into a Go identifier", opt.Name)
+}
+var ErrDuplicateIdentifier = func(ident string, names ...string) error {
+	return fmt.Errorf("options `%s` would all generate the Go identifier `%s`", strings.Join(names, "`, `"), ident)
+}
+var ErrReservedIdentifier = func(opt Option, ident string) error {
+	return fmt.Errorf("option `%s` would generate the Go identifier `%s`, which the generated package already declares", opt.Name, ident)
+}
+var ErrInvalidDefault = func(opt Option, err error) error {
+	def := opt.Default
+	if opt.Secret {
+		def = redacted
+	}
+	return fmt.Errorf("option `%s` has an invalid default `%s`: %w", opt.Name, def, err)
+}
+var ErrDefaultWhenRequired = func(opt Option) error {
+	return fmt.Errorf("option `%s` is required, so it can't have a default", opt.Name)
+}
+
+// how the defaults of secret options are shown in errors, like gofig masks secrets
+const redacted = "******"
+
+// the identifiers the generated package declares besides the ones of each option
+var reservedIdents = map[string]bool{
+	"GF":               true,
+	"InitOpts":         true,
+	"Load":             true,
+	"LoadWithSettings": true,
+}
+
+// what the generated code needs to know about each type
+type typeInfo struct {
+	gfType   gofig.GfType
+	constant string // the name of the gofig.GfType constant
+	goType   string
+	getter   string // the Get-family method of gofig.Gofig
+}
+
+var types = map[string]typeInfo{
+	"bool":              {gofig.TypeBool, "TypeBool", "bool", "GetBool"},
+	"int":               {gofig.TypeInt, "TypeInt", "int", "GetInt"},
+	"float":             {gofig.TypeFloat, "TypeFloat", "float64", "GetFloat"},
+	"string":            {gofig.TypeString, "TypeString", "string", "GetString"},
+	"[]string":          {gofig.TypeStringSlice, "TypeStringSlice", "[]string", "GetStringSlice"},
+	"[]int":             {gofig.TypeIntSlice, "TypeIntSlice", "[]int", "GetIntSlice"},
+	"[]float":           {gofig.TypeFloatSlice, "TypeFloatSlice", "[]float64", "GetFloatSlice"},
+	"[]bool":            {gofig.TypeBoolSlice, "TypeBoolSlice", "[]bool", "GetBoolSlice"},
+	"duration":          {gofig.TypeDuration, "TypeDuration", "time.Duration", "GetDuration"},
+	"time":              {gofig.TypeTime, "TypeTime", "time.Time", "GetTime"},
+	"map[string]string": {gofig.TypeStringMap, "TypeStringMap", "map[string]string", "GetStringMap"},
+}
+
+/*
+ReadSchema reads a schema from a YAML (.yaml, .yml) or JSON (.json) file.
+*/
+func ReadSchema(path string) (Schema, error) {
+	var schema Schema
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return schema, ErrReadingSchema(path, err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &schema)
+	case ".json":
+		err = json.Unmarshal(data, &schema)
+	default:
+		return schema, ErrUnknownSchemaFormat(path)
+	}
+	if err != nil {
+		return schema, ErrReadingSchema(path, err)
+	}
+	return schema, nil
+}
+
+/*
+Generate returns the gofmt-ed source of the config package described by the schema.
+source is the name of the schema file, mentioned in the generated header.
+*/
+func Generate(schema Schema, source string) ([]byte, error) {
+	if len(schema.Options) == 0 {
+		return nil, ErrNoOptions
+	}
+	pkg := schema.Package
+	if pkg == "" {
+		pkg = "config"
+	}
+
+	idents := make([]string, len(schema.Options))
+	namesByIdent := map[string][]string{}
+	usesTime := false
+	for i, opt := range schema.Options {
+		info, ok := types[opt.Type]
+		if !ok {
+			return nil, ErrUnknownOptionType(opt)
+		}
+		if info.gfType == gofig.TypeDuration || info.gfType == gofig.TypeTime {
+			usesTime = true
+		}
+		ident, ok := identifier(opt.Name)
+		if !ok {
+			return nil, ErrInvalidOptionName(opt)
+		}
+		idents[i] = ident
+		namesByIdent[ident] = append(namesByIdent[ident], opt.Name)
+	}
+	for i, ident := range idents {
+		if names := namesByIdent[ident]; len(names) > 1 {
+			return nil, ErrDuplicateIdentifier(ident, names...)
+		}
+		// each option also declares <ident>GfId, which the accessor of another option could be named
+		if reservedIdents[ident] || (strings.HasSuffix(ident, "GfId") && namesByIdent[strings.TrimSuffix(ident, "GfId")] != nil) {
+			return nil, ErrReservedIdentifier(schema.Options[i], ident)
+		}
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by gofig-gen from %s. DO NOT EDIT.\n\n", filepath.Base(source))
+	fmt.Fprintf(&b, "package %s\n\n", pkg)
+	b.WriteString("import (\n")
+	if usesTime {
+		b.WriteString("\"time\"\n\n")
+	}
+	b.WriteString("\"github.com/ippontech/gofig\"\n)\n\n")
+
+	b.WriteString("var (\n")
+	for _, ident := range idents {
+		fmt.Fprintf(&b, "%sGfId gofig.Id\n", ident)
+	}
+	b.WriteString(")\n\n")
+
+	b.WriteString("// GF holds the config values once Load has been called\nvar GF gofig.Gofig\n\n")
+
+	b.WriteString("// InitOpts returns the config options described by the schema\n")
+	b.WriteString("func InitOpts() []gofig.InitOpt {\nreturn []gofig.InitOpt{\n")
+	for i, opt := range schema.Options {
+		if err := writeInitOpt(&b, opt, idents[i]); err != nil {
+			return nil, err
+		}
+	}
+	b.WriteString("}\n}\n\n")
+
+	b.WriteString("// Load initializes GF from the environment\n")
+	b.WriteString("func Load() error {\nreturn LoadWithSettings(gofig.Settings{})\n}\n\n")
+	b.WriteString("// LoadWithSettings initializes GF with gofig.InitWithSettings\n")
+	b.WriteString("func LoadWithSettings(settings gofig.Settings) error {\nvar err error\nGF, err = gofig.InitWithSettings(InitOpts(), settings)\nreturn err\n}\n")
+
+	for i, opt := range schema.Options {
+		info := types[opt.Type]
+		fmt.Fprintf(&b, "\n// %s returns the value of %s, or its zero value if GF isn't loaded.", idents[i], opt.Name)
+		if opt.Description != "" {
+			fmt.Fprintf(&b, "\n// %s", strings.ReplaceAll(opt.Description, "\n", "\n// "))
+		}
+		fmt.Fprintf(&b, "\nfunc %s() %s {\nval, _ := GF.%s(%sGfId)\nreturn val\n}\n", idents[i], info.goType, info.getter, idents[i])
+	}
+
+	return format.Source(b.Bytes())
+}
+
+func writeInitOpt(b *bytes.Buffer, opt Option, ident string) error {
+	info := types[opt.Type]
+
+	b.WriteString("{\n")
+	fmt.Fprintf(b, "Name: %s,\n", strconv.Quote(opt.Name))
+	fmt.Fprintf(b, "Description: %s,\n", strconv.Quote(opt.Description))
+	fmt.Fprintf(b, "Type: gofig.%s,\n", info.constant)
+	fmt.Fprintf(b, "Required: %v,\n", opt.Required)
+	if opt.Required {
+		if opt.Default != "" {
+			return ErrDefaultWhenRequired(opt)
+		}
+	} else {
+		literal, err := defaultLiteral(opt, info)
+		if err != nil {
+			return ErrInvalidDefault(opt, err)
+		}
+		fmt.Fprintf(b, "Default: %s,\n", literal)
+	}
+	fmt.Fprintf(b, "IdPtr: &%sGfId,\n", ident)
+	if opt.Separator != "" {
+		fmt.Fprintf(b, "Separator: %s,\n", strconv.Quote(opt.Separator))
+	}
+	if opt.NoTrim {
+		b.WriteString("NoTrim: true,\n")
+	}
+	if opt.Secret {
+		b.WriteString("Secret: true,\n")
+	}
+	if opt.FromFile {
+		b.WriteString("FromFile: true,\n")
+	}
+	b.WriteString("},\n")
+	return nil
+}
+
+/*
+defaultLiteral returns the Go literal of the default of an option.
+The default is converted by gofig itself, so it means exactly what it would mean if it was set in the environment.
+*/
+func defaultLiteral(opt Option, info typeInfo) (string, error) {
+	val := zeroValue(info.gfType)
+	if opt.Default != "" {
+		var err error
+		if val, err = convert(opt, info); err != nil {
+			return "", err
+		}
+	}
+
+	switch v := val.(type) {
+	case float64:
+		return "float64(" + strconv.FormatFloat(v, 'g', -1, 64) + ")", nil
+	case time.Duration:
+		return durationLiteral(v), nil
+	case time.Time:
+		return timeLiteral(v), nil
+	}
+	return fmt.Sprintf("%#v", val), nil
+}
+
+func convert(opt Option, info typeInfo) (any, error) {
+	var id gofig.Id
+	gf, err := gofig.InitWithSources([]gofig.InitOpt{{
+		Name:      opt.Name,
+		Type:      info.gfType,
+		Required:  true,
+		IdPtr:     &id,
+		Separator: opt.Separator,
+		NoTrim:    opt.NoTrim,
+		Secret:    opt.Secret,
+	}}, gofig.MapSource{opt.Name: opt.Default})
+	if err != nil {
+		return nil, err
+	}
+	if opt.Secret {
+		secret, err := gf.GetSecret(id)
+		return secret.Reveal(), err
+	}
+	return gf.Get(id)
+}
+
+func zeroValue(t gofig.GfType) any {
+	switch t {
+	case gofig.TypeBool:
+		return false
+	case gofig.TypeInt:
+		return 0
+	case gofig.TypeFloat:
+		return 0.0
+	case gofig.TypeString:
+		return ""
+	case gofig.TypeStringSlice:
+		return []string{}
+	case gofig.TypeIntSlice:
+		return []int{}
+	case gofig.TypeFloatSlice:
+		return []float64{}
+	case gofig.TypeBoolSlice:
+		return []bool{}
+	case gofig.TypeDuration:
+		return time.Duration(0)
+	case gofig.TypeTime:
+		return time.Time{}
+	}
+	return map[string]string{}
+}
+
+// durationLiteral writes a duration with the largest unit that divides it (e.g. 90 * time.Second)
+func durationLiteral(d time.Duration) string {
+	units := []struct {
+		unit time.Duration
+		name string
+	}{
+		{time.Hour, "time.Hour"},
+		{time.Minute, "time.Minute"},
+		{time.Second, "time.Second"},
+		{time.Millisecond, "time.Millisecond"},
+		{time.Microsecond, "time.Microsecond"},
+	}
+	for _, u := range units {
+		if d != 0 && d%u.unit == 0 {
+			return fmt.Sprintf("%d * %s", d/u.unit, u.name)
+		}
+	}
+	return fmt.Sprintf("time.Duration(%d)", d)
+}
+
+func timeLiteral(t time.Time) string {
+	if t.IsZero() {
+		return "time.Time{}"
+	}
+	loc := "time.UTC"
+	if _, offset := t.Zone(); offset != 0 || t.Location() != time.UTC {
+		loc = fmt.Sprintf("time.FixedZone(%q, %d)", "", offset)
+	}
+	return fmt.Sprintf("time.Date(%d, %d, %d, %d, %d, %d, %d, %s)",
+		t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+}
+
+/*
+identifier turns the name of a config option into an exported Go identifier (e.g. DATABASE_HOST becomes DatabaseHost).
+Letters after the first one of each word are lowercased.
+*/
+func identifier(name string) (string, bool) {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var ident strings.Builder
+	for _, word := range words {
+		runes := []rune(strings.ToLower(word))
+		runes[0] = unicode.ToUpper(runes[0])
+		ident.WriteString(string(runes))
+	}
+
+	s := ident.String()
+	if s == "" || !unicode.IsUpper([]rune(s)[0]) || !token.IsIdentifier(s) {
+		return "", false
+	}
+	return s, true
+}
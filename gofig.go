@@ -3,18 +3,26 @@ package gofig
 import (
 	"errors"
 	"fmt"
-	"os"
 	"reflect"
 	"strconv"
 	"strings"
+	"sync/atomic"
+	"time"
 )
 
 const (
-	TypeBool   GfType = 0
-	TypeInt    GfType = 1
-	TypeFloat  GfType = 2
-	TypeString GfType = 3
-	numTypes   GfType = 4
+	TypeBool        GfType = 0
+	TypeInt         GfType = 1
+	TypeFloat       GfType = 2
+	TypeString      GfType = 3
+	TypeStringSlice GfType = 4
+	TypeIntSlice    GfType = 5
+	TypeFloatSlice  GfType = 6
+	TypeBoolSlice   GfType = 7
+	TypeDuration    GfType = 8
+	TypeTime        GfType = 9
+	TypeStringMap   GfType = 10
+	numTypes        GfType = 11
 )
 
 var typeNames = []string{
@@ -22,14 +30,54 @@ var typeNames = []string{
 	"int",
 	"float",
 	"string",
+	"[]string",
+	"[]int",
+	"[]float",
+	"[]bool",
+	"duration",
+	"time",
+	"map[string]string",
+}
+
+// the Go type of the values of each GfType. Defaults must be exactly this type.
+var goTypes = [numTypes]reflect.Type{
+	reflect.TypeOf(false),
+	reflect.TypeOf(0),
+	reflect.TypeOf(0.0),
+	reflect.TypeOf(""),
+	reflect.TypeOf([]string{}),
+	reflect.TypeOf([]int{}),
+	reflect.TypeOf([]float64{}),
+	reflect.TypeOf([]bool{}),
+	reflect.TypeOf(time.Duration(0)),
+	reflect.TypeOf(time.Time{}),
+	reflect.TypeOf(map[string]string{}),
 }
 
 type GfType int
 
+/*
+EmptyPolicy is what a config option set as an empty string in a source means.
+A config option that no source has is always unset: it gets its default, or fails if it is required.
+*/
+type EmptyPolicy int
+
+const (
+	EmptyDefault EmptyPolicy = 0 // EmptyAsZero for TypeString, slice types and TypeStringMap. EmptyAsUnset for every other type.
+	EmptyAsUnset EmptyPolicy = 1 // Treat the config option as if no source had it
+	EmptyAsZero  EmptyPolicy = 2 // Use the zero value of the type ("", 0, false, an empty slice or map...)
+	EmptyIsError EmptyPolicy = 3 // Fail with an *EmptyValueError
+)
+
 type Id struct {
 	valid  bool
 	t      GfType // the GfType num will correspond to the index in the Gofig.valsByType slice
 	valIdx int    // the index of the value in the slice of the corresponding GfType
+	issuer uint64 // the instance of the Gofig that issued the Id
+	secret bool   // whether the config option is a secret
+
+	keyType GfType // the GfType the Key this Id belongs to is for. Only set when isKey is true.
+	isKey   bool
 }
 
 /*
@@ -40,21 +88,42 @@ valsByType has this structure:
 	[]int,
 	[]float64,
 	[]string,
+	[][]string,
+	[][]int,
+	[][]float64,
+	[][]bool,
+	[]time.Duration,
+	[]time.Time,
+	[]map[string]string,
 
 ]
 */
 type Gofig struct {
 	initialized bool
-	valsByType  [numTypes]any // slice of slices corresponding to the different types the config options could be.
+	valsByType  [numTypes]any      // slice of slices corresponding to the different types the config options could be.
+	idsByName   map[string]Id      // the Id of each config option by its name
+	instance    uint64             // identifies this Gofig, so Ids issued by another one are rejected
+	origins     [numTypes][]Origin // where each value came from, laid out like valsByType
+	initOpts    []InitOpt          // the config options, in the order they were passed to Init
 }
 
+// the instance of the last Gofig initialized
+var lastInstance atomic.Uint64
+
 type InitOpt struct {
-	Name        string // The name of the config option (e.g. "ENV_VAR_A")
-	Description string // A description of the config option
-	Type        GfType // The type of the config option (e.g. TypeBool, TypeInt, TypeFloat, TypeString)
-	Required    bool   // Whether the config option is required
-	Default     any    // The default value of the config option. Doesn't do anything if the config option is required.
-	IdPtr       *Id    // Pointer to the Id of the config option. This is where you store the Id. The Id value be set after the call to Init.
+	Name        string      // The name of the config option (e.g. "ENV_VAR_A")
+	Description string      // A description of the config option
+	Type        GfType      // The type of the config option (e.g. TypeBool, TypeInt, TypeFloat, TypeString, TypeStringSlice, TypeDuration)
+	Required    bool        // Whether the config option is required
+	Default     any         // The default value of the config option. Doesn't do anything if the config option is required.
+	IdPtr       *Id         // Pointer to the Id of the config option. This is where you store the Id. The Id value be set after the call to Init.
+	Separator   string      // Separator between the elements of slice and map types when they are set as a string (e.g. in the environment). Defaults to ",".
+	NoTrim      bool        // Whether to keep the whitespace around the elements of slice and map types when they are set as a string. By default it is trimmed.
+	Empty       EmptyPolicy // What a value set as an empty string means (e.g. `export FOO=`). See EmptyPolicy.
+	Secret      bool        // Whether the value is a secret (e.g. a password). Secrets are masked in errors, docs and dumps, and Get returns them as a Secret.
+	FromFile    bool        // Whether NAME_FILE can name a file to read the value from when NAME isn't set (e.g. a Docker or Kubernetes secret). See FileSuffix.
+
+	Validators []Validator // Rules the value of the config option must follow (e.g. Min(1), OneOf("a", "b")). Checked by Init after the value is converted.
 }
 
 /*
@@ -68,26 +137,47 @@ type InitOpt struct {
 var ErrNoInputOpts = errors.New("no initOpts provided. must provice initOpts to initialize a Gofig object")
 var ErrInvalidId = errors.New("invalid id")
 var ErrNotInitialized = errors.New("Gofig not initialized. Call Init() first")
+var ErrForeignId = errors.New("id was issued by another Gofig. Ids only work with the Gofig returned by the Init call that set them")
+var ErrNoSources = errors.New("no sources provided. must provide at least one source to look up config values in")
+var ErrNilSource = errors.New("nil source provided")
+var ErrUnknownConfigName = func(name string) error {
+	return fmt.Errorf("config `%s` is not one of the initOpts", name)
+}
+var ErrUnknownType = func(initOpt InitOpt) error {
+	return fmt.Errorf("config: `%v`. type: `%d` is not a known GfType", initOpt.Name, initOpt.Type)
+}
+
+// The constructors below return the typed errors in errors.go. Match them with errors.Is and errors.As.
+
+var ErrDuplicateConfigName = func(name string, indexes ...int) error {
+	return &DuplicateNameError{Name: name, Indexes: indexes}
+}
+var ErrNilIdPtr = func(initOpt InitOpt) error {
+	return &NilIdPtrError{Name: initOpt.Name}
+}
+var ErrReusedIdPtr = func(names ...string) error {
+	return &ReusedIdPtrError{Names: names}
+}
 var ErrDefaultValueIsWrongTypeWhenNotRequired = func(initOpt InitOpt) error {
-	return fmt.Errorf(
-		"config: `%v`. type: `%v`. default value of `%v` is not of type `%v`",
-		initOpt.Name,
-		typeNames[initOpt.Type],
-		initOpt.Default,
-		typeNames[initOpt.Type],
-	)
+	return &DefaultError{Name: initOpt.Name, Type: initOpt.Type, Required: false, Default: initOpt.Default, Secret: initOpt.Secret}
 }
 var ErrRequiredConfigNotSet = func(name string) error {
-	return fmt.Errorf("required config option %s not set", name)
+	return &RequiredNotSetError{Name: name}
 }
 var ErrDefaultNotNilWhenRequired = func(initOpt InitOpt) error {
-	return fmt.Errorf("config: `%v`. required: true. default value: `%v`. default value must be nil when config is required", initOpt.Name, initOpt.Default)
+	return &DefaultError{Name: initOpt.Name, Type: initOpt.Type, Required: true, Default: initOpt.Default, Secret: initOpt.Secret}
 }
 var ErrDefaultIsNilWhenNotRequired = func(initOpt InitOpt) error {
-	return fmt.Errorf("config: `%v`. required: false. default value: `nil`. default value must not be nil when config is not required", initOpt.Name)
+	return &DefaultError{Name: initOpt.Name, Type: initOpt.Type, Required: false, Default: nil}
 }
 var ErrWrongTypeSetInEnvironment = func(initOpt InitOpt, valFromEnviron string) error {
-	return fmt.Errorf("config `%s` of type `%s` was not set as `%s` in environment. environment value: `%s`", initOpt.Name, typeNames[initOpt.Type], typeNames[initOpt.Type], valFromEnviron)
+	return &ConversionError{Name: initOpt.Name, Type: initOpt.Type, Raw: valFromEnviron, Source: envSourceLabel, Secret: initOpt.Secret}
+}
+var ErrEmptyValueSet = func(initOpt InitOpt, sourceLabel string) error {
+	return &EmptyValueError{Name: initOpt.Name, Source: sourceLabel}
+}
+var ErrWrongTypeSetInSource = func(initOpt InitOpt, valFromSource string, sourceLabel string) error {
+	return &ConversionError{Name: initOpt.Name, Type: initOpt.Type, Raw: valFromSource, Source: sourceLabel, Secret: initOpt.Secret}
 }
 
 /**********************
@@ -97,29 +187,110 @@ var ErrWrongTypeSetInEnvironment = func(initOpt InitOpt, valFromEnviron string)
 ***********************/
 
 func isDefaultTypeCorrect(initOpt InitOpt) bool {
-	var GfTypeMapReflectionKind = map[GfType]reflect.Kind{
-		TypeBool:   reflect.Bool,
-		TypeInt:    reflect.Int,
-		TypeFloat:  reflect.Float64,
-		TypeString: reflect.String,
-	}
-
 	if !initOpt.Required {
-		defaultValKind := reflect.TypeOf(initOpt.Default).Kind()
-		if defaultValKind != GfTypeMapReflectionKind[initOpt.Type] {
+		if reflect.TypeOf(initOpt.Default) != goTypes[initOpt.Type] {
 			return false
 		}
 	}
 	return true
 }
 
-func validateCommonGetInputs(gfInitializd bool, id Id) error {
-	if !gfInitializd {
+// newValsByType returns valsByType with an empty slice of the right type for each GfType
+func newValsByType() [numTypes]any {
+	return [numTypes]any{
+		[]bool(nil),
+		[]int(nil),
+		[]float64(nil),
+		[]string(nil),
+		[][]string(nil),
+		[][]int(nil),
+		[][]float64(nil),
+		[][]bool(nil),
+		[]time.Duration(nil),
+		[]time.Time(nil),
+		[]map[string]string(nil),
+	}
+}
+
+// appendValue appends val to the slice of its type in valsByType and returns its index
+func appendValue(valsByType *[numTypes]any, t GfType, val any) int {
+	switch t {
+	case TypeBool:
+		return appendTyped[bool](valsByType, t, val)
+	case TypeInt:
+		return appendTyped[int](valsByType, t, val)
+	case TypeFloat:
+		return appendTyped[float64](valsByType, t, val)
+	case TypeString:
+		return appendTyped[string](valsByType, t, val)
+	case TypeStringSlice:
+		return appendTyped[[]string](valsByType, t, val)
+	case TypeIntSlice:
+		return appendTyped[[]int](valsByType, t, val)
+	case TypeFloatSlice:
+		return appendTyped[[]float64](valsByType, t, val)
+	case TypeBoolSlice:
+		return appendTyped[[]bool](valsByType, t, val)
+	case TypeDuration:
+		return appendTyped[time.Duration](valsByType, t, val)
+	case TypeTime:
+		return appendTyped[time.Time](valsByType, t, val)
+	case TypeStringMap:
+		return appendTyped[map[string]string](valsByType, t, val)
+	}
+	return -1
+}
+
+func appendTyped[T any](valsByType *[numTypes]any, t GfType, val any) int {
+	vals := valsByType[t].([]T)
+	valsByType[t] = append(vals, val.(T))
+	return len(vals)
+}
+
+// getVal returns the value of the config option with the Id passed in, which must be of type t
+func getVal[T any](gf *Gofig, id Id, t GfType) (T, error) {
+	var zero T
+	err := validateCommonGetInputs(gf, id)
+	if err != nil {
+		return zero, err
+	}
+	if id.t != t {
+		return zero, ErrInvalidId
+	}
+	vals := gf.valsByType[t].([]T)
+	if id.valIdx >= len(vals) {
+		return zero, ErrInvalidId
+	}
+	return vals[id.valIdx], nil
+}
+
+// getSliceCopy is getVal for slice types. It returns a copy so the value in Gofig can't be changed.
+func getSliceCopy[T any](gf *Gofig, id Id, t GfType) ([]T, error) {
+	val, err := getVal[[]T](gf, id, t)
+	if err != nil {
+		return nil, err
+	}
+	return append([]T{}, val...), nil
+}
+
+func copyMap(m map[string]string) map[string]string {
+	cp := make(map[string]string, len(m))
+	for k, v := range m {
+		cp[k] = v
+	}
+	return cp
+}
+
+func validateCommonGetInputs(gf *Gofig, id Id) error {
+	if !gf.initialized {
 		return ErrNotInitialized
 	}
 	if !id.valid {
 		return ErrInvalidId
 	}
+	if id.issuer != gf.instance {
+		return ErrForeignId
+	}
 	if id.t < 0 || id.t >= numTypes {
 		return ErrInvalidId
 	}
@@ -138,270 +309,347 @@ func validateCommonGetInputs(gfInitializd bool, id Id) error {
 
 /*
 DocString returns a string that contains the documentation for the config options passed in.
+It's the same as DocStringFormat(initOpts, DocText).
 */
 func DocString(initOpts []InitOpt) (string, error) {
-	if len(initOpts) == 0 {
-		return "", ErrNoInputOpts
-	}
-
-	var docs string
-
-	for _, initOpt := range initOpts {
-		docs += fmt.Sprintf(
-			"%s\n\tDescription: %s\n\tType: %s\n\tRequired: %v\n",
-			initOpt.Name,
-			initOpt.Description,
-			typeNames[initOpt.Type],
-			initOpt.Required,
-		)
+	return DocStringFormat(initOpts, DocText)
+}
 
-		if !initOpt.Required {
-			docs += fmt.Sprintf("\tDefault: %v\n", initOpt.Default)
-		}
-	}
+/*
+Settings customize how InitWithSettings initializes a Gofig object.
+The zero value looks values up in the environment, like Init.
+*/
+type Settings struct {
+	Sources     []Source     // Where to look values up, in order. The first source that has a value for a config option wins. Defaults to the environment.
+	Constraints []Constraint // Rules between config options (e.g. Requires("A", "B")). Checked by Init once every config option has a value.
 
-	return docs, nil
+	BoolVocabulary BoolVocabulary // The words that mean true and false for bool types. Defaults to DefaultBoolVocabulary.
 
+	FromFile    bool  // Whether every config option can be read from the file named by NAME_FILE, as if they all had FromFile set
+	MaxFileSize int64 // The largest file, in bytes, read for a config option. Defaults to DefaultMaxFileSize.
 }
 
 /*
 Init initializes the Gofig object with the config options passed in.
+Values are looked up in the environment. See InitWithSources to use other sources.
 If Gofig has already been initialized, Init will return an error.
 */
 func Init(initOpts []InitOpt) (Gofig, error) {
-	gf := Gofig{}
+	return InitWithSettings(initOpts, Settings{})
+}
 
-	var valsBool []bool
-	var valsInt []int
-	var valsFloat []float64
-	var valsString []string
+/*
+InitWithSources initializes the Gofig object with the config options passed in, looking up each value in the sources passed in.
+Sources are consulted in order and the first source that has a value for a config option wins.
+*/
+func InitWithSources(initOpts []InitOpt, sources ...Source) (Gofig, error) {
+	if len(sources) == 0 {
+		return Gofig{valsByType: newValsByType()}, ErrNoSources
+	}
+	return InitWithSettings(initOpts, Settings{Sources: sources})
+}
+
+/*
+InitWithSettings initializes the Gofig object with the config options passed in, using the settings passed in.
+Problems with config options don't stop Init. It returns all of them in an *InitError.
+*/
+func InitWithSettings(initOpts []InitOpt, settings Settings) (Gofig, error) {
+	gf := Gofig{valsByType: newValsByType(), instance: lastInstance.Add(1)}
+
+	sources := settings.Sources
+	bools := settings.BoolVocabulary.orDefault()
+	files := newValueFiles(settings)
+	if len(sources) == 0 {
+		sources = []Source{EnvSource{}}
+	}
 
 	if len(initOpts) == 0 {
 		return gf, ErrNoInputOpts
 	}
-
-	for _, initOpt := range initOpts {
-		if initOpt.Required && initOpt.Default != nil {
-			return gf, ErrDefaultNotNilWhenRequired(initOpt)
-		}
-		if !initOpt.Required && initOpt.Default == nil {
-			return gf, ErrDefaultIsNilWhenNotRequired(initOpt)
+	for _, source := range sources {
+		if source == nil {
+			return gf, ErrNilSource
 		}
+	}
 
-		initOpt.IdPtr.t = initOpt.Type
-
-		switch initOpt.Type {
-		case TypeBool:
-
-			if ok := isDefaultTypeCorrect(initOpt); !ok {
-				return gf, ErrDefaultValueIsWrongTypeWhenNotRequired(initOpt)
-			}
-
-			var val bool
-			if !initOpt.Required {
-				val = initOpt.Default.(bool)
-			}
-
-			valStr, exists := os.LookupEnv(initOpt.Name)
-			if !exists && initOpt.Required {
-				return gf, ErrRequiredConfigNotSet(initOpt.Name)
-			}
-
-			valConv := strings.ToUpper(valStr) == "TRUE"
-
-			if valConv != val {
-				val = valConv
-			}
-
-			initOpt.IdPtr.valIdx = len(valsBool)
-			valsBool = append(valsBool, val)
-
-		case TypeInt:
-
-			if ok := isDefaultTypeCorrect(initOpt); !ok {
-				return gf, ErrDefaultValueIsWrongTypeWhenNotRequired(initOpt)
-			}
-
-			var val int
-			if !initOpt.Required {
-				val = initOpt.Default.(int)
-			}
+	// every problem found, so they can all be reported at once
+	initErr := &InitError{}
+	// whether each config option was found in a source, and its value, for checking constraints
+	resolved := make(map[string]resolvedOpt, len(initOpts))
 
-			valStr, exists := os.LookupEnv(initOpt.Name)
-			if !exists && initOpt.Required {
-				return gf, ErrRequiredConfigNotSet(initOpt.Name)
-			}
+	// options with duplicate names, nil IdPtrs or reused Ids are reported up front and not initialized
+	badOpts := checkInitOpts(initOpts, initErr)
 
-			valConv, err := strconv.Atoi(valStr)
-			if err != nil {
-				return gf, ErrWrongTypeSetInEnvironment(initOpt, valStr)
-			}
+	for i, initOpt := range initOpts {
+		if badOpts[i] {
+			resolved[initOpt.Name] = resolvedOpt{t: initOpt.Type, failed: true}
+			continue
+		}
 
-			if valConv != val {
-				val = valConv
-			}
+		val, origin, err := initOne(initOpt, sources, bools, files)
+		if err != nil {
+			initErr.add(initOpt.Name, origin.Label, err)
+			resolved[initOpt.Name] = resolvedOpt{t: initOpt.Type, failed: true}
+			continue
+		}
+		resolved[initOpt.Name] = resolvedOpt{t: initOpt.Type, set: origin.Kind != SourceDefault, val: val}
 
-			initOpt.IdPtr.valIdx = len(valsInt)
-			valsInt = append(valsInt, val)
+		initOpt.IdPtr.valIdx = appendValue(&gf.valsByType, initOpt.Type, val)
+		if initOpt.Secret {
+			origin.Raw = redacted
+		}
+		gf.origins[initOpt.Type] = append(gf.origins[initOpt.Type], origin)
+	}
 
-		case TypeFloat:
+	for _, constraint := range settings.Constraints {
+		if err := constraint.check(resolved); err != nil {
+			initErr.add(strings.Join(constraint.names, ", "), "", err)
+		}
+	}
 
-			if ok := isDefaultTypeCorrect(initOpt); !ok {
-				return gf, ErrDefaultValueIsWrongTypeWhenNotRequired(initOpt)
-			}
+	if len(initErr.Errors) > 0 {
+		return gf, initErr
+	}
 
-			var val float64
-			if !initOpt.Required {
-				val = initOpt.Default.(float64)
-			}
+	gf.initOpts = append([]InitOpt{}, initOpts...)
+	gf.idsByName = make(map[string]Id, len(initOpts))
+	for _, opt := range initOpts {
+		opt.IdPtr.valid = true
+		opt.IdPtr.issuer = gf.instance
+		gf.idsByName[opt.Name] = *opt.IdPtr
+	}
+	gf.initialized = true
+	return gf, nil
+}
 
-			valStr, exists := os.LookupEnv(initOpt.Name)
-			if !exists && initOpt.Required {
-				return gf, ErrRequiredConfigNotSet(initOpt.Name)
-			}
+/*
+checkInitOpts adds an error to initErr for each name used by more than one config option,
+each config option with a nil IdPtr and each IdPtr used by more than one config option.
+It returns the indexes of those config options.
+*/
+func checkInitOpts(initOpts []InitOpt, initErr *InitError) map[int]bool {
+	badOpts := map[int]bool{}
 
-			valConv, err := strconv.ParseFloat(valStr, 64)
-			if err != nil {
-				return gf, ErrWrongTypeSetInEnvironment(initOpt, valStr)
-			}
+	var names []string
+	idxsByName := map[string][]int{}
+	var idPtrs []*Id
+	idxsByIdPtr := map[*Id][]int{}
 
-			if valConv != val {
-				val = valConv
-			}
+	for i, initOpt := range initOpts {
+		if _, seen := idxsByName[initOpt.Name]; !seen {
+			names = append(names, initOpt.Name)
+		}
+		idxsByName[initOpt.Name] = append(idxsByName[initOpt.Name], i)
 
-			initOpt.IdPtr.valIdx = len(valsFloat)
-			valsFloat = append(valsFloat, val)
+		if initOpt.IdPtr == nil {
+			initErr.add(initOpt.Name, "", ErrNilIdPtr(initOpt))
+			badOpts[i] = true
+			continue
+		}
+		if _, seen := idxsByIdPtr[initOpt.IdPtr]; !seen {
+			idPtrs = append(idPtrs, initOpt.IdPtr)
+		}
+		idxsByIdPtr[initOpt.IdPtr] = append(idxsByIdPtr[initOpt.IdPtr], i)
+	}
 
-		case TypeString:
+	for _, name := range names {
+		idxs := idxsByName[name]
+		if len(idxs) < 2 {
+			continue
+		}
+		initErr.add(name, "", ErrDuplicateConfigName(name, idxs...))
+		for _, i := range idxs {
+			badOpts[i] = true
+		}
+	}
 
-			if ok := isDefaultTypeCorrect(initOpt); !ok {
-				return gf, ErrDefaultValueIsWrongTypeWhenNotRequired(initOpt)
-			}
+	for _, idPtr := range idPtrs {
+		idxs := idxsByIdPtr[idPtr]
+		if len(idxs) < 2 {
+			continue
+		}
+		sharing := make([]string, len(idxs))
+		for j, i := range idxs {
+			sharing[j] = initOpts[i].Name
+			badOpts[i] = true
+		}
+		initErr.add(strings.Join(sharing, ", "), "", ErrReusedIdPtr(sharing...))
+	}
 
-			var val string
-			if !initOpt.Required {
-				val = initOpt.Default.(string)
-			}
+	return badOpts
+}
 
-			valStr, exists := os.LookupEnv(initOpt.Name)
-			if !exists && initOpt.Required {
-				return gf, ErrRequiredConfigNotSet(initOpt.Name)
-			}
+func joinInts(ints []int) string {
+	strs := make([]string, len(ints))
+	for i, n := range ints {
+		strs[i] = strconv.Itoa(n)
+	}
+	return strings.Join(strs, ", ")
+}
 
-			if valStr != val {
-				val = valStr
-			}
+// initOne checks the definition of one config option, then looks up, converts and validates its value
+func initOne(initOpt InitOpt, sources []Source, bools BoolVocabulary, files valueFiles) (val any, origin Origin, err error) {
+	if initOpt.Type < 0 || initOpt.Type >= numTypes {
+		return nil, Origin{}, ErrUnknownType(initOpt)
+	}
+	if initOpt.Required && initOpt.Default != nil {
+		return nil, Origin{}, ErrDefaultNotNilWhenRequired(initOpt)
+	}
+	if !initOpt.Required && initOpt.Default == nil {
+		return nil, Origin{}, ErrDefaultIsNilWhenNotRequired(initOpt)
+	}
+	if ok := isDefaultTypeCorrect(initOpt); !ok {
+		return nil, Origin{}, ErrDefaultValueIsWrongTypeWhenNotRequired(initOpt)
+	}
 
-			initOpt.IdPtr.valIdx = len(valsString)
-			valsString = append(valsString, val)
-		}
+	if initOpt.IdPtr.isKey && initOpt.IdPtr.keyType != initOpt.Type {
+		return nil, Origin{}, ErrKeyTypeMismatch(initOpt)
+	}
+	if err := checkValidators(initOpt); err != nil {
+		return nil, Origin{}, err
 	}
 
-	gf.valsByType[TypeBool] = valsBool
-	gf.valsByType[TypeInt] = valsInt
-	gf.valsByType[TypeFloat] = valsFloat
-	gf.valsByType[TypeString] = valsString
+	initOpt.IdPtr.t = initOpt.Type
+	initOpt.IdPtr.secret = initOpt.Secret
+	// Ids that are initialized again stop working with the Gofig that issued them before
+	initOpt.IdPtr.issuer = 0
 
-	for _, opt := range initOpts {
-		opt.IdPtr.valid = true
+	val, origin, err = resolveValue(initOpt, sources, bools, files)
+	if err != nil {
+		return nil, origin, err
 	}
-	gf.initialized = true
-	return gf, nil
+	if err := validate(initOpt, val); err != nil {
+		return nil, origin, err
+	}
+	return val, origin, nil
 }
 
 /*
 Get returns the value of the config option corresponding to the Id passed in.
+Slice and map values are copies, so changing them doesn't change the value in Gofig.
+Secrets are returned as a Secret, so they are masked if the value is printed. The typed Get-family functions return them as is.
 If the Id is invalid, Get will return an error.
 If Gofig has not been initialized, Get will return an error.
 */
 func (gf *Gofig) Get(id Id) (any, error) {
-
-	if !gf.initialized {
-		return nil, ErrNotInitialized
-	}
-	if !id.valid {
-		return nil, ErrInvalidId
+	val, err := gf.get(id)
+	if err != nil {
+		return nil, err
 	}
-	if id.t < 0 || id.t >= numTypes {
-		return nil, ErrInvalidId
+	if id.secret {
+		return Secret{val: val}, nil
 	}
-	if id.valIdx < 0 {
-		return nil, ErrInvalidId
+	return val, nil
+}
+
+// get is Get without wrapping secrets
+func (gf *Gofig) get(id Id) (any, error) {
+	err := validateCommonGetInputs(gf, id)
+	if err != nil {
+		return nil, err
 	}
 
+	var val any
 	switch id.t {
 	case TypeBool:
-		if id.valIdx >= len(gf.valsByType[id.t].([]bool)) {
-			return nil, ErrInvalidId
-		}
-		return gf.valsByType[id.t].([]bool)[id.valIdx], nil
-
+		val, err = getVal[bool](gf, id, id.t)
 	case TypeInt:
-		if id.valIdx >= len(gf.valsByType[id.t].([]int)) {
-			return nil, ErrInvalidId
-		}
-		return gf.valsByType[id.t].([]int)[id.valIdx], nil
-
+		val, err = getVal[int](gf, id, id.t)
 	case TypeFloat:
-		if id.valIdx >= len(gf.valsByType[id.t].([]float64)) {
-			return nil, ErrInvalidId
-		}
-		return gf.valsByType[id.t].([]float64)[id.valIdx], nil
-
+		val, err = getVal[float64](gf, id, id.t)
 	case TypeString:
-		if id.valIdx >= len(gf.valsByType[id.t].([]string)) {
-			return nil, ErrInvalidId
-		}
-		return gf.valsByType[id.t].([]string)[id.valIdx], nil
-
+		val, err = getVal[string](gf, id, id.t)
+	case TypeStringSlice:
+		val, err = gf.GetStringSlice(id)
+	case TypeIntSlice:
+		val, err = gf.GetIntSlice(id)
+	case TypeFloatSlice:
+		val, err = gf.GetFloatSlice(id)
+	case TypeBoolSlice:
+		val, err = gf.GetBoolSlice(id)
+	case TypeDuration:
+		val, err = gf.GetDuration(id)
+	case TypeTime:
+		val, err = gf.GetTime(id)
+	case TypeStringMap:
+		val, err = gf.GetStringMap(id)
 	}
-
-	// if somehow we get here, just return not valid id
-	return nil, ErrInvalidId
+	if err != nil {
+		return nil, err
+	}
+	return val, nil
 }
 
-// More Get-family functions for bool, int, float64, and string
+/*
+IdByName returns the Id of the config option with the name passed in, and whether there is one.
+Handy when names are only known at runtime (e.g. in an admin endpoint). Prefer Ids otherwise.
+*/
+func (gf *Gofig) IdByName(name string) (Id, bool) {
+	id, ok := gf.idsByName[name]
+	return id, ok
+}
 
-func (gf *Gofig) GetBool(id Id) (bool, error) {
-	err := validateCommonGetInputs(gf.initialized, id)
-	if err != nil {
-		return false, err
+/*
+GetByName returns the value of the config option with the name passed in, like Get.
+If there is no config option with that name, GetByName will return an error.
+*/
+func (gf *Gofig) GetByName(name string) (any, error) {
+	if !gf.initialized {
+		return nil, ErrNotInitialized
 	}
-	if id.valIdx >= len(gf.valsByType[id.t].([]bool)) {
-		return false, ErrInvalidId
+	id, ok := gf.IdByName(name)
+	if !ok {
+		return nil, ErrUnknownConfigName(name)
 	}
-	return gf.valsByType[id.t].([]bool)[id.valIdx], nil
+	return gf.Get(id)
+}
+
+// More Get-family functions for each type. They return ErrInvalidId if the Id is for a config option of another type.
+
+func (gf *Gofig) GetBool(id Id) (bool, error) {
+	return getVal[bool](gf, id, TypeBool)
 }
 
 func (gf *Gofig) GetInt(id Id) (int, error) {
-	err := validateCommonGetInputs(gf.initialized, id)
-	if err != nil {
-		return 0, err
-	}
-	if id.valIdx >= len(gf.valsByType[id.t].([]int)) {
-		return 0, ErrInvalidId
-	}
-	return gf.valsByType[id.t].([]int)[id.valIdx], nil
+	return getVal[int](gf, id, TypeInt)
 }
 
 func (gf *Gofig) GetFloat(id Id) (float64, error) {
-	err := validateCommonGetInputs(gf.initialized, id)
-	if err != nil {
-		return 0, err
-	}
-	if id.valIdx >= len(gf.valsByType[id.t].([]float64)) {
-		return 0.0, ErrInvalidId
-	}
-	return gf.valsByType[id.t].([]float64)[id.valIdx], nil
+	return getVal[float64](gf, id, TypeFloat)
 }
 
 func (gf *Gofig) GetString(id Id) (string, error) {
-	err := validateCommonGetInputs(gf.initialized, id)
+	return getVal[string](gf, id, TypeString)
+}
+
+func (gf *Gofig) GetStringSlice(id Id) ([]string, error) {
+	return getSliceCopy[string](gf, id, TypeStringSlice)
+}
+
+func (gf *Gofig) GetIntSlice(id Id) ([]int, error) {
+	return getSliceCopy[int](gf, id, TypeIntSlice)
+}
+
+func (gf *Gofig) GetFloatSlice(id Id) ([]float64, error) {
+	return getSliceCopy[float64](gf, id, TypeFloatSlice)
+}
+
+func (gf *Gofig) GetBoolSlice(id Id) ([]bool, error) {
+	return getSliceCopy[bool](gf, id, TypeBoolSlice)
+}
+
+func (gf *Gofig) GetDuration(id Id) (time.Duration, error) {
+	return getVal[time.Duration](gf, id, TypeDuration)
+}
+
+func (gf *Gofig) GetTime(id Id) (time.Time, error) {
+	return getVal[time.Time](gf, id, TypeTime)
+}
+
+func (gf *Gofig) GetStringMap(id Id) (map[string]string, error) {
+	val, err := getVal[map[string]string](gf, id, TypeStringMap)
 	if err != nil {
-		return "", err
-	}
-	if id.valIdx >= len(gf.valsByType[id.t].([]string)) {
-		return "", ErrInvalidId
+		return nil, err
 	}
-	return gf.valsByType[id.t].([]string)[id.valIdx], nil
+	return copyMap(val), nil
 }
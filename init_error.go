@@ -0,0 +1,60 @@
+package gofig
+
+import (
+	"fmt"
+	"strings"
+)
+
+/*
+OptionError is a problem Init found with one config option.
+*/
+type OptionError struct {
+	Name   string // The name of the config option. For constraints, the names of the config options in the constraint.
+	Source string // The label of the source the value came from (e.g. "environment", "config.yaml:12"). Empty if no source had a value.
+	Err    error  // What is wrong
+}
+
+func (e *OptionError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *OptionError) Unwrap() error {
+	return e.Err
+}
+
+/*
+InitError is every problem Init found with the config options, so they can all be fixed at once.
+It unwraps to each *OptionError, and through them to the underlying errors, so errors.Is and errors.As see all of them.
+*/
+type InitError struct {
+	Errors []*OptionError
+}
+
+func (e *InitError) add(name string, source string, err error) {
+	e.Errors = append(e.Errors, &OptionError{Name: name, Source: source, Err: err})
+}
+
+/*
+Error returns the message of the problem when there is only one.
+Otherwise it lists the message of every problem on its own line.
+*/
+func (e *InitError) Error() string {
+	if len(e.Errors) == 1 {
+		return e.Errors[0].Error()
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d config problems:", len(e.Errors))
+	for _, optErr := range e.Errors {
+		b.WriteString("\n\t- ")
+		b.WriteString(optErr.Error())
+	}
+	return b.String()
+}
+
+func (e *InitError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, optErr := range e.Errors {
+		errs[i] = optErr
+	}
+	return errs
+}
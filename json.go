@@ -0,0 +1,169 @@
+package gofig
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"strconv"
+)
+
+/*
+NewJsonSource reads and parses the JSON config file at the path passed in.
+Strings, numbers and booleans are used as is. Arrays and objects are turned into compact JSON (e.g. `["a","b"]`).
+Null values are treated as not set. A file that is empty or only whitespace has no values, like empty YAML and TOML files.
+*/
+func NewJsonSource(path string) (*FileSource, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, ErrReadingConfigFile(path, err)
+	}
+
+	fs := newFileSource(path)
+	if len(bytes.TrimSpace(data)) == 0 {
+		return fs, nil
+	}
+	jw := jsonWalker{
+		data: data,
+		dec:  json.NewDecoder(bytes.NewReader(data)),
+		fs:   fs,
+	}
+	jw.dec.UseNumber()
+
+	tok, err := jw.dec.Token()
+	if err != nil {
+		return nil, ErrParsingConfigFile(path, err)
+	}
+	if tok != json.Delim('{') {
+		return nil, ErrConfigFileNotMapping(path)
+	}
+	if _, _, err := jw.addObject(nil); err != nil {
+		return nil, err
+	}
+	if _, err := jw.dec.Token(); err != io.EOF {
+		return nil, ErrParsingConfigFile(path, errors.New("unexpected data after top-level object"))
+	}
+	return fs, nil
+}
+
+// jsonWalker walks the tokens of a JSON document, adding every value it finds to a FileSource
+type jsonWalker struct {
+	data []byte
+	dec  *json.Decoder
+	fs   *FileSource
+}
+
+// line returns the line of the last token read
+func (jw *jsonWalker) line() int {
+	return bytes.Count(jw.data[:jw.dec.InputOffset()], []byte("\n")) + 1
+}
+
+/*
+addObject adds the members of the object whose opening brace was just read.
+It returns the members of the object if their values are all scalars.
+*/
+func (jw *jsonWalker) addObject(keyPath []string) (map[string]string, bool, error) {
+	pairs := make(map[string]string)
+	scalarsOnly := true
+
+	for jw.dec.More() {
+		keyTok, err := jw.dec.Token()
+		if err != nil {
+			return nil, false, ErrParsingConfigFile(jw.fs.path, err)
+		}
+		key := keyTok.(string)
+		childPath := append(append([]string{}, keyPath...), key)
+		line := jw.line()
+
+		start := jw.dec.InputOffset()
+		valTok, err := jw.dec.Token()
+		if err != nil {
+			return nil, false, ErrParsingConfigFile(jw.fs.path, err)
+		}
+
+		entry := fileEntry{line: line}
+		switch val := valTok.(type) {
+		case nil:
+			continue
+		case string:
+			entry.raw = val
+		case json.Number:
+			entry.raw = val.String()
+		case bool:
+			entry.raw = strconv.FormatBool(val)
+		case json.Delim:
+			scalarsOnly = false
+			if val == '{' {
+				entry.pairs, entry.isMap, err = jw.addObject(childPath)
+			} else {
+				entry.elems, entry.isList, err = jw.readArray()
+			}
+			if err != nil {
+				return nil, false, err
+			}
+			entry.raw = jw.compact(start)
+		}
+
+		if err := jw.fs.add(childPath, entry); err != nil {
+			return nil, false, err
+		}
+		if scalarsOnly {
+			pairs[key] = entry.raw
+		}
+	}
+
+	// closing brace
+	if _, err := jw.dec.Token(); err != nil {
+		return nil, false, ErrParsingConfigFile(jw.fs.path, err)
+	}
+	if !scalarsOnly {
+		return nil, false, nil
+	}
+	return pairs, true, nil
+}
+
+/*
+readArray reads up to and including the closing bracket of the array whose opening bracket was just read.
+It returns the elements of the array if they are all scalars.
+*/
+func (jw *jsonWalker) readArray() ([]string, bool, error) {
+	elems := []string{}
+	scalarsOnly := true
+	for depth := 1; depth > 0; {
+		tok, err := jw.dec.Token()
+		if err != nil {
+			return nil, false, ErrParsingConfigFile(jw.fs.path, err)
+		}
+		switch val := tok.(type) {
+		case json.Delim:
+			if val == '[' || val == '{' {
+				depth++
+				scalarsOnly = false
+			} else {
+				depth--
+			}
+		case string:
+			elems = append(elems, val)
+		case json.Number:
+			elems = append(elems, val.String())
+		case bool:
+			elems = append(elems, strconv.FormatBool(val))
+		case nil:
+			elems = append(elems, "")
+		}
+	}
+	if !scalarsOnly {
+		return nil, false, nil
+	}
+	return elems, true, nil
+}
+
+// compact returns the compacted JSON between start and the last token read
+func (jw *jsonWalker) compact(start int64) string {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, bytes.TrimLeft(jw.data[start:jw.dec.InputOffset()], " \t\r\n:")); err != nil {
+		return ""
+	}
+	return buf.String()
+}
@@ -0,0 +1,70 @@
+package gofig
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+/*
+ValueType is the set of Go types config option values can have, one for each GfType.
+*/
+type ValueType interface {
+	bool | int | float64 | string | []string | []int | []float64 | []bool | time.Duration | time.Time | map[string]string
+}
+
+var ErrKeyTypeMismatch = func(initOpt InitOpt) error {
+	return fmt.Errorf("config `%s` has type `%s` but its Key is for `%s`", initOpt.Name, typeNames[initOpt.Type], typeNames[initOpt.IdPtr.keyType])
+}
+
+/*
+Key is an Id that knows the Go type of its config option, so getting its value with Value is type-checked at compile time:
+
+	var portKey gofig.Key[int]
+
+	gf, err := gofig.Init([]gofig.InitOpt{
+		{Name: "PORT", Type: gofig.TypeInt, Required: true, IdPtr: portKey.IdPtr()},
+	})
+	port, err := gofig.Value(&gf, portKey) // port is an int
+
+Init returns ErrKeyTypeMismatch if the Type of the InitOpt doesn't go with T.
+*/
+type Key[T ValueType] struct {
+	id Id
+}
+
+/*
+IdPtr returns the pointer to the Id of the Key, to put in the IdPtr of an InitOpt.
+*/
+func (k *Key[T]) IdPtr() *Id {
+	var zero T
+	k.id.keyType, _ = gfTypeOf(reflect.TypeOf(zero))
+	k.id.isKey = true
+	return &k.id
+}
+
+/*
+Id returns the Id of the Key, for use with the Get-family functions of Gofig.
+*/
+func (k Key[T]) Id() Id {
+	return k.id
+}
+
+/*
+Value returns the value of the config option corresponding to the Key passed in.
+Slice and map values are copies, so changing them doesn't change the value in Gofig.
+If the Key was initialized by another Gofig, Value will return ErrForeignId. If it was never initialized, Value will return ErrInvalidId.
+If Gofig has not been initialized, Value will return an error.
+*/
+func Value[T ValueType](gf *Gofig, key Key[T]) (T, error) {
+	var zero T
+	val, err := gf.get(key.id)
+	if err != nil {
+		return zero, err
+	}
+	typed, ok := val.(T)
+	if !ok {
+		return zero, ErrInvalidId
+	}
+	return typed, nil
+}
@@ -0,0 +1,66 @@
+package gofig
+
+import (
+	"fmt"
+	"strings"
+)
+
+/*
+Origin is where the value of a config option came from.
+*/
+type Origin struct {
+	Kind  SourceKind // The kind of source (e.g. SourceEnv, SourceFile). SourceDefault if no source had the config option.
+	Label string     // The label of the source (e.g. "environment", "config.yaml:12", "--database-host"). Empty for defaults.
+	Raw   string     // The value before conversion. Lists and maps held natively by a file are written the way they would be set as a string.
+}
+
+func (o Origin) String() string {
+	if o.Label == "" {
+		return o.Kind.String()
+	}
+	return fmt.Sprintf("%s `%s`", o.Kind, o.Label)
+}
+
+/*
+Origin returns where the value of the config option corresponding to the Id passed in came from.
+It returns the same errors as Get.
+*/
+func (gf *Gofig) Origin(id Id) (Origin, error) {
+	err := validateCommonGetInputs(gf, id)
+	if err != nil {
+		return Origin{}, err
+	}
+	origins := gf.origins[id.t]
+	if id.valIdx >= len(origins) {
+		return Origin{}, ErrInvalidId
+	}
+	return origins[id.valIdx], nil
+}
+
+/*
+Explain returns a line for each config option, in the order they were passed to Init,
+with its effective value and where it came from. Secrets are masked:
+
+	DATABASE_HOST=db.example.com (env `environment`)
+	DATABASE_PORT=5432 (default)
+*/
+func (gf *Gofig) Explain() (string, error) {
+	if !gf.initialized {
+		return "", ErrNotInitialized
+	}
+
+	var b strings.Builder
+	for _, initOpt := range gf.initOpts {
+		id := gf.idsByName[initOpt.Name]
+		val, err := gf.get(id)
+		if err != nil {
+			return "", err
+		}
+		origin, err := gf.Origin(id)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&b, "%s=%s (%s)\n", initOpt.Name, formatSecret(initOpt, val), origin)
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,61 @@
+package gofig
+
+import (
+	"fmt"
+	"io"
+)
+
+// what secrets are replaced with in everything gofig prints
+const redacted = "******"
+
+/*
+Secret holds the value of a config option with Secret set.
+Printing it with any fmt verb, or marshaling it to JSON, gives a mask instead of the value.
+Use Reveal to get the value.
+*/
+type Secret struct {
+	val any
+}
+
+/*
+Reveal returns the value of the secret.
+*/
+func (s Secret) Reveal() any {
+	return s.val
+}
+
+func (s Secret) String() string {
+	return redacted
+}
+
+func (s Secret) GoString() string {
+	return redacted
+}
+
+func (s Secret) Format(f fmt.State, verb rune) {
+	io.WriteString(f, redacted)
+}
+
+func (s Secret) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + redacted + `"`), nil
+}
+
+/*
+GetSecret returns the value of the config option corresponding to the Id passed in as a Secret.
+It works for any config option, secret or not.
+*/
+func (gf *Gofig) GetSecret(id Id) (Secret, error) {
+	val, err := gf.get(id)
+	if err != nil {
+		return Secret{}, err
+	}
+	return Secret{val: val}, nil
+}
+
+// formatSecret is formatValue, masking the value of secrets
+func formatSecret(initOpt InitOpt, val any) string {
+	if initOpt.Secret {
+		return redacted
+	}
+	return formatValue(initOpt, val)
+}
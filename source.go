@@ -0,0 +1,149 @@
+package gofig
+
+import (
+	"fmt"
+	"os"
+)
+
+// label reported by EnvSource. Used to keep the original environment error wording.
+const envSourceLabel = "environment"
+
+/*
+Source is somewhere config values can be looked up, such as the environment, a file or command-line flags.
+Lookup returns the raw string value of the config option with the given name, whether it was found,
+and a label describing where the value came from (e.g. "environment", "config.yaml:12").
+*/
+type Source interface {
+	Lookup(name string) (val string, found bool, label string)
+}
+
+/*
+EnvSource looks up config options in the environment of the current process.
+This is the source Init uses.
+*/
+type EnvSource struct{}
+
+func (EnvSource) Lookup(name string) (string, bool, string) {
+	val, found := os.LookupEnv(name)
+	return val, found, envSourceLabel
+}
+
+/*
+MapSource looks up config options in a map of names to raw string values.
+Handy for tests and for values computed at runtime.
+*/
+type MapSource map[string]string
+
+func (ms MapSource) Lookup(name string) (string, bool, string) {
+	val, found := ms[name]
+	return val, found, "map"
+}
+
+// lookupSources returns the value from the first source that has the config option, and that source
+func lookupSources(sources []Source, name string) (val string, found bool, label string, from Source) {
+	for _, source := range sources {
+		if val, found, label := source.Lookup(name); found {
+			return val, true, label, source
+		}
+	}
+	return "", false, "", nil
+}
+
+/*
+lookupList is lookupSources for slice types.
+If the first source that has the config option holds it as a native list, the elements are returned with isList set.
+Otherwise the raw string is returned for Init to split.
+*/
+func lookupList(sources []Source, name string) (raw string, elems []string, isList bool, found bool, label string, from Source) {
+	for _, source := range sources {
+		if listSource, ok := source.(ListSource); ok {
+			if elems, found, label := listSource.LookupList(name); found {
+				return "", elems, true, true, label, source
+			}
+		}
+		if raw, found, label := source.Lookup(name); found {
+			return raw, nil, false, true, label, source
+		}
+	}
+	return "", nil, false, false, "", nil
+}
+
+/*
+ListSource is implemented by sources that can hold lists natively (e.g. arrays in a YAML or JSON file).
+For slice types, Init uses the elements from LookupList instead of splitting the raw string from Lookup.
+*/
+type ListSource interface {
+	Source
+	LookupList(name string) (vals []string, found bool, label string)
+}
+
+/*
+StringMapSource is implemented by sources that can hold maps natively (e.g. objects in a YAML or JSON file).
+For TypeStringMap, Init uses the map from LookupStringMap instead of parsing the raw string from Lookup.
+*/
+type StringMapSource interface {
+	Source
+	LookupStringMap(name string) (vals map[string]string, found bool, label string)
+}
+
+// lookupStringMap is lookupList for TypeStringMap
+func lookupStringMap(sources []Source, name string) (raw string, vals map[string]string, isMap bool, found bool, label string, from Source) {
+	for _, source := range sources {
+		if mapSource, ok := source.(StringMapSource); ok {
+			if vals, found, label := mapSource.LookupStringMap(name); found {
+				return "", vals, true, true, label, source
+			}
+		}
+		if raw, found, label := source.Lookup(name); found {
+			return raw, nil, false, true, label, source
+		}
+	}
+	return "", nil, false, false, "", nil
+}
+
+/*
+SourceKind is the kind of place a value came from. See Gofig.Origin.
+*/
+type SourceKind int
+
+const (
+	SourceDefault SourceKind = 0 // The Default of the InitOpt
+	SourceEnv     SourceKind = 1 // EnvSource
+	SourceFile    SourceKind = 2 // A FileSource, such as the ones returned by NewYamlSource
+	SourceFlag    SourceKind = 3 // A FlagSource
+	SourceMap     SourceKind = 4 // A MapSource
+	SourceOther   SourceKind = 5 // A Source that doesn't implement KindSource
+)
+
+var sourceKindNames = []string{"default", "env", "file", "flag", "map", "other"}
+
+func (k SourceKind) String() string {
+	if k < 0 || int(k) >= len(sourceKindNames) {
+		return fmt.Sprintf("SourceKind(%d)", int(k))
+	}
+	return sourceKindNames[k]
+}
+
+/*
+KindSource is implemented by sources that report their SourceKind.
+Values from sources that don't implement it are of SourceOther.
+*/
+type KindSource interface {
+	Source
+	Kind() SourceKind
+}
+
+func (EnvSource) Kind() SourceKind {
+	return SourceEnv
+}
+
+func (MapSource) Kind() SourceKind {
+	return SourceMap
+}
+
+func sourceKind(source Source) SourceKind {
+	if kindSource, ok := source.(KindSource); ok {
+		return kindSource.Kind()
+	}
+	return SourceOther
+}
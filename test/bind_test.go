@@ -0,0 +1,133 @@
+package gofig
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ippontech/gofig"
+)
+
+type bindConfig struct {
+	Host    string            `gofig:"DATABASE_HOST" desc:"The database host" required:"true"`
+	Port    int               `gofig:"DATABASE_PORT" desc:"The database port" default:"5432"`
+	Replica []string          `gofig:"REPLICA_HOSTS" desc:"Read replicas" sep:";"`
+	Timeout time.Duration     `gofig:"CONNECT_TIMEOUT" desc:"How long to wait" default:"5s"`
+	Labels  map[string]string `gofig:"LABELS"`
+	Ignored string
+	Skipped string `gofig:"-"`
+}
+
+func Test_Bind_FillsTaggedFields(t *testing.T) {
+	t.Setenv("DATABASE_HOST", "db.local")
+	t.Setenv("DATABASE_PORT", "6543")
+	t.Setenv("REPLICA_HOSTS", "a;b")
+	t.Setenv("CONNECT_TIMEOUT", "1m")
+	t.Setenv("LABELS", "team=core")
+
+	cfg := bindConfig{Ignored: "keep", Skipped: "keep"}
+	if err := gofig.Bind(&cfg); err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+
+	expected := bindConfig{
+		Host:    "db.local",
+		Port:    6543,
+		Replica: []string{"a", "b"},
+		Timeout: time.Minute,
+		Labels:  map[string]string{"team": "core"},
+		Ignored: "keep",
+		Skipped: "keep",
+	}
+	if !reflect.DeepEqual(cfg, expected) {
+		t.Errorf("expected: `%+v`, got: `%+v`", expected, cfg)
+	}
+}
+
+func Test_BindWithSettings_UsesSources(t *testing.T) {
+	var cfg struct {
+		Debug bool `gofig:"DEBUG" required:"true"`
+	}
+
+	err := gofig.BindWithSettings(&cfg, gofig.Settings{Sources: []gofig.Source{gofig.MapSource{"DEBUG": "true"}}})
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+	if !cfg.Debug {
+		t.Errorf("expected: `%v`, got: `%v`", true, cfg.Debug)
+	}
+}
+
+func Test_Bind_Err_When_RequiredNotSet_LeavesStructUnchanged(t *testing.T) {
+	var cfg struct {
+		Host string `gofig:"HOST" required:"true"`
+		Port int    `gofig:"PORT" required:"true"`
+	}
+	cfg.Host = "unchanged"
+
+	err := gofig.BindWithSettings(&cfg, gofig.Settings{Sources: []gofig.Source{gofig.MapSource{"HOST": "changed"}}})
+
+	var notSetErr *gofig.RequiredNotSetError
+	if !errors.As(err, &notSetErr) || notSetErr.Name != "PORT" {
+		t.Errorf("expected a *RequiredNotSetError for `PORT`, got: `%v`", err)
+	}
+	if cfg.Host != "unchanged" {
+		t.Errorf("expected: `%v`, got: `%v`", "unchanged", cfg.Host)
+	}
+}
+
+func Test_Bind_Err_When_NotStructPtr(t *testing.T) {
+	var cfg bindConfig
+
+	errActual := gofig.Bind(cfg)
+	errExpected := gofig.ErrBindTargetNotStructPtr
+	if errActual != errExpected {
+		t.Error(ErrErrorsDoNotMatch(errExpected, errActual))
+	}
+}
+
+func Test_Bind_Err_When_FieldTypeUnsupported(t *testing.T) {
+	var cfg struct {
+		Port int32 `gofig:"PORT" required:"true"`
+	}
+
+	errActual := gofig.Bind(&cfg)
+	errExpected := gofig.ErrUnsupportedFieldType(reflect.TypeOf(cfg).Field(0))
+	if errActual == nil || errActual.Error() != errExpected.Error() {
+		t.Error(ErrErrorsDoNotMatch(errExpected, errActual))
+	}
+}
+
+func Test_Bind_Err_When_DefaultTagIsWrongType(t *testing.T) {
+	var cfg struct {
+		Port int `gofig:"PORT" default:"eighty"`
+	}
+
+	errActual := gofig.Bind(&cfg)
+	if !errors.Is(errActual, gofig.ErrConversion) {
+		t.Errorf("expected errors.Is(err, ErrConversion), got: `%v`", errActual)
+	}
+}
+
+func Test_StructInitOpts_DocString(t *testing.T) {
+	var cfg struct {
+		Host string `gofig:"HOST" desc:"The host" required:"true"`
+		Port int    `gofig:"PORT" desc:"The port" default:"80"`
+	}
+
+	initOpts, err := gofig.StructInitOpts(&cfg)
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+	actualDocStr, err := gofig.DocString(initOpts)
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+
+	expectedDocStr := "HOST\n\tDescription: The host\n\tType: string\n\tRequired: true\n" +
+		"PORT\n\tDescription: The port\n\tType: int\n\tRequired: false\n\tDefault: 80\n"
+	if actualDocStr != expectedDocStr {
+		t.Errorf("expected: `%v`, got: `%v`", expectedDocStr, actualDocStr)
+	}
+}
@@ -0,0 +1,106 @@
+package gofig
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/ippontech/gofig"
+)
+
+func Test_Init_BoolAcceptsExtendedWords(t *testing.T) {
+	tests := map[string]bool{
+		"true": true, "TRUE": true, "1": true, "t": true, "yes": true, "Y": true, "on": true,
+		"false": false, "0": false, "F": false, "no": false, "n": false, "OFF": false,
+	}
+
+	for raw, expected := range tests {
+		var fooId gofig.Id
+
+		gf, err := gofig.InitWithSources([]gofig.InitOpt{
+			{Name: "FOO", Type: gofig.TypeBool, Required: true, IdPtr: &fooId},
+		}, gofig.MapSource{"FOO": raw})
+		if err != nil {
+			t.Fatal(ErrExpectedNoError(err))
+		}
+		if actual, _ := gf.GetBool(fooId); actual != expected {
+			t.Errorf("`%s`: expected: `%v`, got: `%v`", raw, expected, actual)
+		}
+	}
+}
+
+func Test_Init_Err_When_BoolIsUnrecognised(t *testing.T) {
+	t.Setenv("FOO", "ture")
+
+	var fooId gofig.Id
+
+	initOpt := gofig.InitOpt{Name: "FOO", Type: gofig.TypeBool, Required: true, IdPtr: &fooId}
+
+	_, errActual := gofig.Init([]gofig.InitOpt{initOpt})
+
+	errExpected := gofig.ErrWrongTypeSetInEnvironment(initOpt, "ture")
+	if errActual == nil || errActual.Error() != errExpected.Error() {
+		t.Error(ErrErrorsDoNotMatch(errExpected, errActual))
+	}
+	if !errors.Is(errActual, gofig.ErrConversion) {
+		t.Errorf("expected errors.Is(err, ErrConversion), got: `%v`", errActual)
+	}
+}
+
+func Test_InitWithSettings_CustomBoolVocabulary(t *testing.T) {
+	var featuresId, debugId gofig.Id
+
+	settings := gofig.Settings{
+		Sources:        []gofig.Source{gofig.MapSource{"FEATURES": "enabled, disabled", "DEBUG": "yes"}},
+		BoolVocabulary: gofig.BoolVocabulary{True: []string{"enabled"}, False: []string{"disabled"}},
+	}
+
+	_, errActual := gofig.InitWithSettings([]gofig.InitOpt{
+		{Name: "FEATURES", Type: gofig.TypeBoolSlice, Required: true, IdPtr: &featuresId},
+		{Name: "DEBUG", Type: gofig.TypeBool, Required: true, IdPtr: &debugId},
+	}, settings)
+
+	var convErr *gofig.ConversionError
+	if !errors.As(errActual, &convErr) || convErr.Name != "DEBUG" {
+		t.Fatalf("expected a *ConversionError for `DEBUG`, got: `%v`", errActual)
+	}
+
+	settings.Sources = []gofig.Source{gofig.MapSource{"FEATURES": "enabled, disabled", "DEBUG": "Enabled"}}
+	gf, err := gofig.InitWithSettings([]gofig.InitOpt{
+		{Name: "FEATURES", Type: gofig.TypeBoolSlice, Required: true, IdPtr: &featuresId},
+		{Name: "DEBUG", Type: gofig.TypeBool, Required: true, IdPtr: &debugId},
+	}, settings)
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+	features, _ := gf.GetBoolSlice(featuresId)
+	if !reflect.DeepEqual(features, []bool{true, false}) {
+		t.Errorf("expected: `%v`, got: `%v`", []bool{true, false}, features)
+	}
+	if debug, _ := gf.GetBool(debugId); !debug {
+		t.Errorf("expected: `%v`, got: `%v`", true, debug)
+	}
+}
+
+func Test_BindWithSettings_DefaultTagUsesBoolVocabulary(t *testing.T) {
+	bools := gofig.BoolVocabulary{True: []string{"oui"}, False: []string{"non"}}
+
+	var cfg struct {
+		Debug bool `gofig:"DEBUG" default:"oui"`
+	}
+	err := gofig.BindWithSettings(&cfg, gofig.Settings{Sources: []gofig.Source{gofig.MapSource{}}, BoolVocabulary: bools})
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+	if !cfg.Debug {
+		t.Errorf("expected: `%v`, got: `%v`", true, cfg.Debug)
+	}
+
+	var other struct {
+		Debug bool `gofig:"DEBUG" default:"yes"`
+	}
+	errActual := gofig.BindWithSettings(&other, gofig.Settings{Sources: []gofig.Source{gofig.MapSource{}}, BoolVocabulary: bools})
+	if !errors.Is(errActual, gofig.ErrConversion) {
+		t.Errorf("expected `%v`, got: `%v`", gofig.ErrConversion, errActual)
+	}
+}
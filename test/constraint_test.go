@@ -0,0 +1,159 @@
+package gofig
+
+import (
+	"testing"
+
+	"github.com/ippontech/gofig"
+)
+
+func Test_InitWithSettings_Err_When_RequiresFails(t *testing.T) {
+	var certId gofig.Id
+	var keyId gofig.Id
+
+	_, errActual := gofig.InitWithSettings([]gofig.InitOpt{
+		{Name: "TLS_CERT", Type: gofig.TypeString, Default: "", IdPtr: &certId},
+		{Name: "TLS_KEY", Type: gofig.TypeString, Default: "", IdPtr: &keyId},
+	}, gofig.Settings{
+		Sources:     []gofig.Source{gofig.MapSource{"TLS_CERT": "cert.pem"}},
+		Constraints: []gofig.Constraint{gofig.Requires("TLS_CERT", "TLS_KEY")},
+	})
+
+	errExpected := gofig.ErrConstraintFailed("requires(TLS_CERT, TLS_KEY)", "`TLS_CERT` is set but `TLS_KEY` is not")
+	if errActual == nil || errActual.Error() != errExpected.Error() {
+		t.Error(ErrErrorsDoNotMatch(errExpected, errActual))
+	}
+}
+
+func Test_InitWithSettings_Err_When_MutuallyExclusiveFails(t *testing.T) {
+	var tokenId gofig.Id
+	var passwordId gofig.Id
+
+	_, errActual := gofig.InitWithSettings([]gofig.InitOpt{
+		{Name: "TOKEN", Type: gofig.TypeString, Default: "", IdPtr: &tokenId},
+		{Name: "PASSWORD", Type: gofig.TypeString, Default: "", IdPtr: &passwordId},
+	}, gofig.Settings{
+		Sources:     []gofig.Source{gofig.MapSource{"TOKEN": "t", "PASSWORD": "p"}},
+		Constraints: []gofig.Constraint{gofig.MutuallyExclusive("TOKEN", "PASSWORD")},
+	})
+
+	errExpected := gofig.ErrConstraintFailed("mutuallyExclusive(TOKEN, PASSWORD)", "only one may be set, but `TOKEN`, `PASSWORD` are set")
+	if errActual == nil || errActual.Error() != errExpected.Error() {
+		t.Error(ErrErrorsDoNotMatch(errExpected, errActual))
+	}
+}
+
+func Test_InitWithSettings_ExactlyOneOf(t *testing.T) {
+	initOpts := func() []gofig.InitOpt {
+		var aId, bId, cId gofig.Id
+		return []gofig.InitOpt{
+			{Name: "A", Type: gofig.TypeString, Default: "", IdPtr: &aId},
+			{Name: "B", Type: gofig.TypeString, Default: "", IdPtr: &bId},
+			{Name: "C", Type: gofig.TypeString, Default: "", IdPtr: &cId},
+		}
+	}
+	constraints := []gofig.Constraint{gofig.ExactlyOneOf("A", "B", "C")}
+
+	_, err := gofig.InitWithSettings(initOpts(), gofig.Settings{
+		Sources:     []gofig.Source{gofig.MapSource{"B": "b"}},
+		Constraints: constraints,
+	})
+	if err != nil {
+		t.Error(ErrExpectedNoError(err))
+	}
+
+	_, errActual := gofig.InitWithSettings(initOpts(), gofig.Settings{
+		Sources:     []gofig.Source{gofig.MapSource{}},
+		Constraints: constraints,
+	})
+	errExpected := gofig.ErrConstraintFailed("exactlyOneOf(A, B, C)", "one must be set, but none are")
+	if errActual == nil || errActual.Error() != errExpected.Error() {
+		t.Error(ErrErrorsDoNotMatch(errExpected, errActual))
+	}
+}
+
+func Test_InitWithSettings_RequiredWhen(t *testing.T) {
+	initOpts := func() []gofig.InitOpt {
+		var engineId, passwordId gofig.Id
+		return []gofig.InitOpt{
+			{Name: "DATABASE_ENGINE", Type: gofig.TypeString, Required: true, IdPtr: &engineId},
+			{Name: "DATABASE_PASSWORD", Type: gofig.TypeString, Default: "", IdPtr: &passwordId},
+		}
+	}
+	constraints := []gofig.Constraint{
+		gofig.RequiredWhen("DATABASE_PASSWORD", gofig.NotEquals("DATABASE_ENGINE", "sqlite")),
+	}
+
+	_, err := gofig.InitWithSettings(initOpts(), gofig.Settings{
+		Sources:     []gofig.Source{gofig.MapSource{"DATABASE_ENGINE": "sqlite"}},
+		Constraints: constraints,
+	})
+	if err != nil {
+		t.Error(ErrExpectedNoError(err))
+	}
+
+	_, errActual := gofig.InitWithSettings(initOpts(), gofig.Settings{
+		Sources:     []gofig.Source{gofig.MapSource{"DATABASE_ENGINE": "postgres"}},
+		Constraints: constraints,
+	})
+	errExpected := gofig.ErrConstraintFailed(
+		"requiredWhen(DATABASE_PASSWORD, DATABASE_ENGINE != sqlite)",
+		"`DATABASE_PASSWORD` is required when DATABASE_ENGINE != sqlite",
+	)
+	if errActual == nil || errActual.Error() != errExpected.Error() {
+		t.Error(ErrErrorsDoNotMatch(errExpected, errActual))
+	}
+}
+
+func Test_InitWithSettings_Err_When_ConstraintRefersToUnknownConfig(t *testing.T) {
+	var fooId gofig.Id
+
+	initOpt := goodStringInitOpt
+	initOpt.IdPtr = &fooId
+
+	_, errActual := gofig.InitWithSettings([]gofig.InitOpt{initOpt}, gofig.Settings{
+		Sources:     []gofig.Source{gofig.MapSource{"FOO": "foo"}},
+		Constraints: []gofig.Constraint{gofig.Requires("FOO", "BAR")},
+	})
+
+	errExpected := gofig.ErrUnknownConfigInConstraint("requires(FOO, BAR)", "BAR")
+	if errActual == nil || errActual.Error() != errExpected.Error() {
+		t.Error(ErrErrorsDoNotMatch(errExpected, errActual))
+	}
+}
+
+func Test_InitWithSettings_Err_When_ConditionValueIsWrongType(t *testing.T) {
+	for _, when := range []gofig.Condition{gofig.Equals("RATE", 2), gofig.NotEquals("RATE", "2")} {
+		var rateId, limitId gofig.Id
+		constraint := gofig.RequiredWhen("LIMIT", when)
+
+		_, errActual := gofig.InitWithSettings([]gofig.InitOpt{
+			{Name: "RATE", Type: gofig.TypeFloat, Required: true, IdPtr: &rateId},
+			{Name: "LIMIT", Type: gofig.TypeInt, Default: 0, IdPtr: &limitId},
+		}, gofig.Settings{
+			Sources:     []gofig.Source{gofig.MapSource{"RATE": "2"}},
+			Constraints: []gofig.Constraint{constraint},
+		})
+
+		errExpected := gofig.ErrConditionValueWrongType(constraint.Rule(), when, gofig.TypeFloat)
+		if errActual == nil || errActual.Error() != errExpected.Error() {
+			t.Error(ErrErrorsDoNotMatch(errExpected, errActual))
+		}
+	}
+}
+
+func Test_InitWithSettings_Err_When_ConstraintIsZeroValue(t *testing.T) {
+	var fooId gofig.Id
+
+	initOpt := goodStringInitOpt
+	initOpt.IdPtr = &fooId
+
+	_, errActual := gofig.InitWithSettings([]gofig.InitOpt{initOpt}, gofig.Settings{
+		Sources:     []gofig.Source{gofig.MapSource{"FOO": "foo"}},
+		Constraints: []gofig.Constraint{{}},
+	})
+
+	errExpected := gofig.ErrConstraintWithoutNames("requires()")
+	if errActual == nil || errActual.Error() != errExpected.Error() {
+		t.Error(ErrErrorsDoNotMatch(errExpected, errActual))
+	}
+}
@@ -0,0 +1,85 @@
+package gofig
+
+import (
+	"os"
+	"testing"
+
+	"github.com/ippontech/gofig"
+)
+
+var syncInitOpts = []gofig.InitOpt{
+	{Name: "HOST", Description: "The host", Type: gofig.TypeString, Required: true},
+}
+
+const syncedBlock = "<!-- gofig:begin -->\n" +
+	"| Name | Type | Required | Default | Validators | Description |\n" +
+	"|---|---|---|---|---|---|\n" +
+	"| `HOST` | `string` | true |  |  | The host |\n" +
+	"<!-- gofig:end -->"
+
+func Test_SyncDocs_ReplacesBlockOnly(t *testing.T) {
+	path := writeTempFile(t, "README.md", "# App\n\n<!-- gofig:begin -->\nold docs\n<!-- gofig:end -->\n\nMore text\n")
+
+	if err := gofig.SyncDocs(path, syncInitOpts); err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+
+	data, _ := os.ReadFile(path)
+	expected := "# App\n\n" + syncedBlock + "\n\nMore text\n"
+	if string(data) != expected {
+		t.Errorf("expected: `%v`, got: `%v`", expected, string(data))
+	}
+
+	if err := gofig.CheckDocs(path, syncInitOpts); err != nil {
+		t.Error(ErrExpectedNoError(err))
+	}
+}
+
+func Test_CheckDocs_Err_When_Stale(t *testing.T) {
+	path := writeTempFile(t, "README.md", "<!-- gofig:begin -->\nold docs\n<!-- gofig:end -->\n")
+
+	errActual := gofig.CheckDocs(path, syncInitOpts)
+	errExpected := gofig.ErrDocsStale(path)
+	if errActual == nil || errActual.Error() != errExpected.Error() {
+		t.Error(ErrErrorsDoNotMatch(errExpected, errActual))
+	}
+
+	data, _ := os.ReadFile(path)
+	if string(data) != "<!-- gofig:begin -->\nold docs\n<!-- gofig:end -->\n" {
+		t.Errorf("CheckDocs changed the file: `%v`", string(data))
+	}
+}
+
+func Test_SyncDocs_Err_When_NoMarkers(t *testing.T) {
+	path := writeTempFile(t, "README.md", "# App\n<!-- gofig:begin -->\n")
+
+	errActual := gofig.SyncDocs(path, syncInitOpts)
+	errExpected := gofig.ErrDocsMarkersNotFound(path)
+	if errActual == nil || errActual.Error() != errExpected.Error() {
+		t.Error(ErrErrorsDoNotMatch(errExpected, errActual))
+	}
+}
+
+func Test_RunDocsCommand(t *testing.T) {
+	path := writeTempFile(t, "README.md", "<!-- gofig:begin --><!-- gofig:end -->\n")
+
+	handled, err := gofig.RunDocsCommand(syncInitOpts, []string{"serve"})
+	if handled || err != nil {
+		t.Errorf("expected other commands to be left alone, got: `%v`, `%v`", handled, err)
+	}
+
+	handled, err = gofig.RunDocsCommand(syncInitOpts, []string{"docs", "sync", "--check", "--file", path})
+	if !handled || err == nil {
+		t.Errorf("expected --check to fail on stale docs, got: `%v`, `%v`", handled, err)
+	}
+
+	handled, err = gofig.RunDocsCommand(syncInitOpts, []string{"docs", "sync", "--file", path})
+	if !handled || err != nil {
+		t.Fatalf("expected docs to be synced, got: `%v`, `%v`", handled, err)
+	}
+
+	data, _ := os.ReadFile(path)
+	if string(data) != syncedBlock+"\n" {
+		t.Errorf("expected: `%v`, got: `%v`", syncedBlock+"\n", string(data))
+	}
+}
@@ -0,0 +1,123 @@
+package gofig
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ippontech/gofig"
+)
+
+var docInitOpts = []gofig.InitOpt{
+	{Name: "ENGINE", Description: "The database engine", Type: gofig.TypeString, Required: true, Validators: []gofig.Validator{gofig.OneOf("postgres", "sqlite")}},
+	{Name: "PORT", Description: "The port | the listener", Type: gofig.TypeInt, Default: 5432, Validators: []gofig.Validator{gofig.Port()}},
+	{Name: "TIMEOUT", Description: "How long to wait", Type: gofig.TypeDuration, Default: 5 * time.Second},
+}
+
+func Test_DocString_ListsValidators(t *testing.T) {
+	expectedDocStr := "ENGINE\n\tDescription: The database engine\n\tType: string\n\tRequired: true\n\tValidators: oneOf(postgres, sqlite)\n" +
+		"PORT\n\tDescription: The port | the listener\n\tType: int\n\tRequired: false\n\tDefault: 5432\n\tValidators: port\n" +
+		"TIMEOUT\n\tDescription: How long to wait\n\tType: duration\n\tRequired: false\n\tDefault: 5s\n"
+
+	actualDocStr, err := gofig.DocString(docInitOpts)
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+	if actualDocStr != expectedDocStr {
+		t.Errorf("expected: `%v`, got: `%v`", expectedDocStr, actualDocStr)
+	}
+}
+
+func Test_DocStringFormat_Markdown(t *testing.T) {
+	expectedDocStr := "| Name | Type | Required | Default | Validators | Description |\n" +
+		"|---|---|---|---|---|---|\n" +
+		"| `ENGINE` | `string` | true |  | `oneOf(postgres, sqlite)` | The database engine |\n" +
+		"| `PORT` | `int` | false | `5432` | `port` | The port \\| the listener |\n" +
+		"| `TIMEOUT` | `duration` | false | `5s` |  | How long to wait |\n"
+
+	actualDocStr, err := gofig.DocStringFormat(docInitOpts, gofig.DocMarkdown)
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+	if actualDocStr != expectedDocStr {
+		t.Errorf("expected: `%v`, got: `%v`", expectedDocStr, actualDocStr)
+	}
+}
+
+func Test_DocStringFormat_JSON(t *testing.T) {
+	actualDocStr, err := gofig.DocStringFormat(docInitOpts, gofig.DocJSON)
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+
+	var schema struct {
+		Type       string                    `json:"type"`
+		Required   []string                  `json:"required"`
+		Properties map[string]map[string]any `json:"properties"`
+	}
+	if err := json.Unmarshal([]byte(actualDocStr), &schema); err != nil {
+		t.Fatalf("doc string is not valid JSON: %v\n%s", err, actualDocStr)
+	}
+
+	if schema.Type != "object" || !reflect.DeepEqual(schema.Required, []string{"ENGINE"}) {
+		t.Errorf("unexpected schema: `%s`", actualDocStr)
+	}
+	expectedEngine := map[string]any{
+		"description":        "The database engine",
+		"type":               "string",
+		"enum":               []any{"postgres", "sqlite"},
+		"x-gofig-type":       "string",
+		"x-gofig-validators": []any{"oneOf(postgres, sqlite)"},
+	}
+	if !reflect.DeepEqual(schema.Properties["ENGINE"], expectedEngine) {
+		t.Errorf("expected: `%v`, got: `%v`", expectedEngine, schema.Properties["ENGINE"])
+	}
+	if schema.Properties["PORT"]["default"] != 5432.0 || schema.Properties["PORT"]["type"] != "integer" {
+		t.Errorf("unexpected PORT: `%v`", schema.Properties["PORT"])
+	}
+	if schema.Properties["TIMEOUT"]["default"] != "5s" || schema.Properties["TIMEOUT"]["format"] != "duration" {
+		t.Errorf("unexpected TIMEOUT: `%v`", schema.Properties["TIMEOUT"])
+	}
+
+	// allowed durations and times are strings like their default, so the schema accepts its own default
+	actualDocStr, err = gofig.DocStringFormat([]gofig.InitOpt{
+		{Name: "WAIT", Type: gofig.TypeDuration, Default: time.Second, Validators: []gofig.Validator{gofig.OneOf(time.Second, time.Minute)}},
+		{Name: "START", Type: gofig.TypeTime, Default: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Validators: []gofig.Validator{gofig.OneOf(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))}},
+	}, gofig.DocJSON)
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+	if err := json.Unmarshal([]byte(actualDocStr), &schema); err != nil {
+		t.Fatalf("doc string is not valid JSON: %v\n%s", err, actualDocStr)
+	}
+	if wait := schema.Properties["WAIT"]; wait["default"] != "1s" || !reflect.DeepEqual(wait["enum"], []any{"1s", "1m0s"}) {
+		t.Errorf("unexpected WAIT: `%v`", wait)
+	}
+	if start := schema.Properties["START"]; start["default"] != "2024-01-01T00:00:00Z" || !reflect.DeepEqual(start["enum"], []any{"2024-01-01T00:00:00Z"}) {
+		t.Errorf("unexpected START: `%v`", start)
+	}
+}
+
+func Test_DocStringFormat_Man(t *testing.T) {
+	expectedDocStr := ".SH ENVIRONMENT\n" +
+		".TP\n.B ENGINE\nThe database engine\n.br\nType: string. Required.\n.br\nAllowed values: postgres, sqlite.\n.br\nValidators: oneOf(postgres, sqlite).\n" +
+		".TP\n.B PORT\nThe port | the listener\n.br\nType: int. Default: 5432.\n.br\nValidators: port.\n" +
+		".TP\n.B TIMEOUT\nHow long to wait\n.br\nType: duration. Default: 5s.\n"
+
+	actualDocStr, err := gofig.DocStringFormat(docInitOpts, gofig.DocMan)
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+	if actualDocStr != expectedDocStr {
+		t.Errorf("expected: `%v`, got: `%v`", expectedDocStr, actualDocStr)
+	}
+}
+
+func Test_DocStringFormat_Err_When_UnknownFormat(t *testing.T) {
+	_, errActual := gofig.DocStringFormat(docInitOpts, gofig.DocFormat(42))
+	errExpected := gofig.ErrUnknownDocFormat(gofig.DocFormat(42))
+	if errActual == nil || errActual.Error() != errExpected.Error() {
+		t.Error(ErrErrorsDoNotMatch(errExpected, errActual))
+	}
+}
@@ -0,0 +1,119 @@
+package gofig
+
+import (
+	"testing"
+
+	"github.com/ippontech/gofig"
+)
+
+func Test_DotenvSource_QuotesCommentsExportAndExpansion(t *testing.T) {
+	t.Setenv("HOME_DIR", "/home/gofig")
+	path := writeTempFile(t, ".env", `
+# a comment
+export DATABASE_HOST=localhost # trailing comment
+DATABASE_USER='$literal # not a comment'
+GREETING="hello\nworld"
+DATA_DIR=${HOME_DIR}/data
+DATABASE_URL="postgres://$DATABASE_HOST/${DATABASE_NAME:-app}"
+export	TABBED=1
+AFTER_TAB=hello	# tab before the comment
+exportED=not exported
+`)
+
+	ds, err := gofig.NewFileSource(path)
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+
+	expected := map[string]string{
+		"DATABASE_HOST": "localhost",
+		"DATABASE_USER": "$literal # not a comment",
+		"GREETING":      "hello\nworld",
+		"DATA_DIR":      "/home/gofig/data",
+		"DATABASE_URL":  "postgres://localhost/app",
+		"TABBED":        "1",
+		"AFTER_TAB":     "hello",
+		"EXPORTED":      "not exported",
+	}
+	for name, expectedVal := range expected {
+		val, found, _ := ds.Lookup(name)
+		if !found || val != expectedVal {
+			t.Errorf("%s: expected: `%v`, got: `%v`", name, expectedVal, val)
+		}
+	}
+}
+
+func Test_DotenvSource_EnvTakesPrecedence(t *testing.T) {
+	t.Setenv("FOO", "from env")
+	path := writeTempFile(t, ".env", "FOO=from dotenv\n")
+
+	var fooId gofig.Id
+
+	initOpt := goodStringInitOpt
+	initOpt.IdPtr = &fooId
+
+	ds, err := gofig.NewDotenvSource(path)
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+
+	gf, err := gofig.InitWithSources([]gofig.InitOpt{initOpt}, gofig.EnvSource{}, ds)
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+
+	foo, _ := gf.GetString(fooId)
+	if foo != "from env" {
+		t.Errorf("expected: `%v`, got: `%v`", "from env", foo)
+	}
+}
+
+func Test_DotenvSource_LastAssignmentWins(t *testing.T) {
+	path := writeTempFile(t, ".env", "A=first\nB=${A}\nA=second\n")
+
+	ds, err := gofig.NewDotenvSource(path)
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+
+	a, found, label := ds.Lookup("A")
+	if !found || a != "second" || label != path+":3" {
+		t.Errorf("expected: `second` from `%s:3`, got: `%v` from `%v`", path, a, label)
+	}
+	b, _, _ := ds.Lookup("B")
+	if b != "first" {
+		t.Errorf("expected: `%v`, got: `%v`", "first", b)
+	}
+}
+
+func Test_DotenvSource_Err_When_ValueIsWrongType(t *testing.T) {
+	path := writeTempFile(t, ".env", "# port\nFOO=abc\n")
+
+	var fooId gofig.Id
+
+	initOpt := goodIntInitOpt
+	initOpt.IdPtr = &fooId
+
+	ds, err := gofig.NewDotenvSource(path)
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+
+	_, errActual := gofig.InitWithSources([]gofig.InitOpt{initOpt}, gofig.EnvSource{}, ds)
+
+	errExpected := gofig.ErrWrongTypeSetInSource(initOpt, "abc", path+":2")
+	if errActual == nil || errActual.Error() != errExpected.Error() {
+		t.Error(ErrErrorsDoNotMatch(errExpected, errActual))
+	}
+}
+
+func Test_DotenvSource_Err_When_LineIsMalformed(t *testing.T) {
+	path := writeTempFile(t, ".env", "FOO=bar\nnot a variable\n")
+
+	_, errActual := gofig.NewDotenvSource(path)
+
+	errExpected := gofig.ErrParsingDotenvLine(path, 2, "expected NAME=value")
+	if errActual == nil || errActual.Error() != errExpected.Error() {
+		t.Error(ErrErrorsDoNotMatch(errExpected, errActual))
+	}
+}
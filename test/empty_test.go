@@ -0,0 +1,117 @@
+package gofig
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/ippontech/gofig"
+)
+
+func Test_Init_DefaultsApply_When_Unset(t *testing.T) {
+	var portId, ratioId, debugId, hostsId gofig.Id
+
+	gf, err := gofig.InitWithSources([]gofig.InitOpt{
+		{Name: "PORT", Type: gofig.TypeInt, Default: 8080, IdPtr: &portId},
+		{Name: "RATIO", Type: gofig.TypeFloat, Default: 0.5, IdPtr: &ratioId},
+		{Name: "DEBUG", Type: gofig.TypeBool, Default: true, IdPtr: &debugId},
+		{Name: "HOSTS", Type: gofig.TypeStringSlice, Default: []string{"a", "b"}, IdPtr: &hostsId},
+	}, gofig.MapSource{})
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+
+	if port, _ := gf.GetInt(portId); port != 8080 {
+		t.Errorf("expected: `%v`, got: `%v`", 8080, port)
+	}
+	if ratio, _ := gf.GetFloat(ratioId); ratio != 0.5 {
+		t.Errorf("expected: `%v`, got: `%v`", 0.5, ratio)
+	}
+	if debug, _ := gf.GetBool(debugId); !debug {
+		t.Errorf("expected: `%v`, got: `%v`", true, debug)
+	}
+	if hosts, _ := gf.GetStringSlice(hostsId); !reflect.DeepEqual(hosts, []string{"a", "b"}) {
+		t.Errorf("expected: `%v`, got: `%v`", []string{"a", "b"}, hosts)
+	}
+}
+
+func Test_Init_SliceDefaultIsCopied(t *testing.T) {
+	var hostsId gofig.Id
+	hosts := []string{"a", "b"}
+
+	gf, err := gofig.InitWithSources([]gofig.InitOpt{
+		{Name: "HOSTS", Type: gofig.TypeStringSlice, Default: hosts, IdPtr: &hostsId},
+	}, gofig.MapSource{})
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+
+	hosts[0] = "changed"
+	if actual, _ := gf.GetStringSlice(hostsId); !reflect.DeepEqual(actual, []string{"a", "b"}) {
+		t.Errorf("changing the default after Init changed the value in Gofig: `%v`", actual)
+	}
+}
+
+func Test_Init_EmptyPolicies(t *testing.T) {
+	tests := []struct {
+		name     string
+		initOpt  gofig.InitOpt
+		expected any
+	}{
+		{"int empty is unset by default", gofig.InitOpt{Type: gofig.TypeInt, Default: 7}, 7},
+		{"bool empty is unset by default", gofig.InitOpt{Type: gofig.TypeBool, Default: true}, true},
+		{"string empty is a value by default", gofig.InitOpt{Type: gofig.TypeString, Default: "default"}, ""},
+		{"slice empty is a value by default", gofig.InitOpt{Type: gofig.TypeIntSlice, Default: []int{1}}, []int{}},
+		{"int empty as zero", gofig.InitOpt{Type: gofig.TypeInt, Default: 7, Empty: gofig.EmptyAsZero}, 0},
+		{"string empty as unset", gofig.InitOpt{Type: gofig.TypeString, Default: "default", Empty: gofig.EmptyAsUnset}, "default"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var fooId gofig.Id
+			initOpt := test.initOpt
+			initOpt.Name = "FOO"
+			initOpt.IdPtr = &fooId
+
+			gf, err := gofig.InitWithSources([]gofig.InitOpt{initOpt}, gofig.MapSource{"FOO": ""})
+			if err != nil {
+				t.Fatal(ErrExpectedNoError(err))
+			}
+			actual, _ := gf.Get(fooId)
+			if !reflect.DeepEqual(actual, test.expected) {
+				t.Errorf("expected: `%v`, got: `%v`", test.expected, actual)
+			}
+		})
+	}
+}
+
+func Test_Init_Err_When_EmptyIsError(t *testing.T) {
+	var fooId gofig.Id
+
+	_, errActual := gofig.InitWithSources([]gofig.InitOpt{
+		{Name: "FOO", Type: gofig.TypeString, Default: "default", Empty: gofig.EmptyIsError, IdPtr: &fooId},
+	}, gofig.MapSource{"FOO": ""})
+
+	if !errors.Is(errActual, gofig.ErrEmptyValue) {
+		t.Errorf("expected errors.Is(err, ErrEmptyValue), got: `%v`", errActual)
+	}
+	var emptyErr *gofig.EmptyValueError
+	if !errors.As(errActual, &emptyErr) || emptyErr.Name != "FOO" || emptyErr.Source != "map" {
+		t.Errorf("expected an *EmptyValueError for `FOO` from `map`, got: `%v`", errActual)
+	}
+}
+
+func Test_Init_Err_When_RequiredIntIsEmpty(t *testing.T) {
+	t.Setenv("FOO", "")
+
+	var fooId gofig.Id
+
+	_, errActual := gofig.Init([]gofig.InitOpt{
+		{Name: "FOO", Type: gofig.TypeInt, Required: true, IdPtr: &fooId},
+	})
+
+	errExpected := gofig.ErrRequiredConfigNotSet("FOO")
+	if errActual == nil || errActual.Error() != errExpected.Error() {
+		t.Error(ErrErrorsDoNotMatch(errExpected, errActual))
+	}
+}
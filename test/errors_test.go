@@ -0,0 +1,112 @@
+package gofig
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ippontech/gofig"
+)
+
+func Test_Init_RequiredNotSetError(t *testing.T) {
+	var fooId gofig.Id
+
+	_, errActual := gofig.InitWithSources([]gofig.InitOpt{
+		{Name: "FOO", Type: gofig.TypeString, Required: true, IdPtr: &fooId},
+	}, gofig.MapSource{})
+
+	if !errors.Is(errActual, gofig.ErrRequiredNotSet) {
+		t.Errorf("expected errors.Is(err, ErrRequiredNotSet), got: `%v`", errActual)
+	}
+	var notSetErr *gofig.RequiredNotSetError
+	if !errors.As(errActual, &notSetErr) {
+		t.Fatalf("expected a *RequiredNotSetError, got: `%v`", errActual)
+	}
+	if notSetErr.Name != "FOO" {
+		t.Errorf("expected: `%v`, got: `%v`", "FOO", notSetErr.Name)
+	}
+}
+
+func Test_Init_ConversionError(t *testing.T) {
+	t.Setenv("PORT", "eighty")
+
+	var portId gofig.Id
+
+	_, errActual := gofig.Init([]gofig.InitOpt{
+		{Name: "PORT", Type: gofig.TypeInt, Required: true, IdPtr: &portId},
+	})
+
+	if !errors.Is(errActual, gofig.ErrConversion) {
+		t.Errorf("expected errors.Is(err, ErrConversion), got: `%v`", errActual)
+	}
+	var convErr *gofig.ConversionError
+	if !errors.As(errActual, &convErr) {
+		t.Fatalf("expected a *ConversionError, got: `%v`", errActual)
+	}
+	expected := gofig.ConversionError{Name: "PORT", Type: gofig.TypeInt, Raw: "eighty", Source: "environment"}
+	if *convErr != expected {
+		t.Errorf("expected: `%+v`, got: `%+v`", expected, *convErr)
+	}
+}
+
+func Test_Init_DefaultError(t *testing.T) {
+	var fooId gofig.Id
+
+	_, errActual := gofig.Init([]gofig.InitOpt{
+		{Name: "FOO", Type: gofig.TypeInt, Required: true, Default: 3, IdPtr: &fooId},
+	})
+
+	if !errors.Is(errActual, gofig.ErrInvalidDefault) {
+		t.Errorf("expected errors.Is(err, ErrInvalidDefault), got: `%v`", errActual)
+	}
+	var defaultErr *gofig.DefaultError
+	if !errors.As(errActual, &defaultErr) {
+		t.Fatalf("expected a *DefaultError, got: `%v`", errActual)
+	}
+	if defaultErr.Name != "FOO" || !defaultErr.Required || defaultErr.Default != 3 {
+		t.Errorf("unexpected fields: `%+v`", *defaultErr)
+	}
+}
+
+func Test_Init_ValidationError(t *testing.T) {
+	t.Setenv("WORKERS", "0")
+
+	var workersId gofig.Id
+
+	_, errActual := gofig.Init([]gofig.InitOpt{
+		{Name: "WORKERS", Type: gofig.TypeInt, Required: true, IdPtr: &workersId, Validators: []gofig.Validator{gofig.Min(1)}},
+	})
+
+	if !errors.Is(errActual, gofig.ErrValidation) {
+		t.Errorf("expected errors.Is(err, ErrValidation), got: `%v`", errActual)
+	}
+	var validationErr *gofig.ValidationError
+	if !errors.As(errActual, &validationErr) {
+		t.Fatalf("expected a *ValidationError, got: `%v`", errActual)
+	}
+	if validationErr.Name != "WORKERS" || validationErr.Rule != "min(1)" {
+		t.Errorf("unexpected fields: `%+v`", *validationErr)
+	}
+}
+
+func Test_Init_ConstraintError(t *testing.T) {
+	var aId, bId gofig.Id
+
+	_, errActual := gofig.InitWithSettings([]gofig.InitOpt{
+		{Name: "A", Type: gofig.TypeString, Required: false, Default: "", IdPtr: &aId},
+		{Name: "B", Type: gofig.TypeString, Required: false, Default: "", IdPtr: &bId},
+	}, gofig.Settings{
+		Sources:     []gofig.Source{gofig.MapSource{"A": "a", "B": "b"}},
+		Constraints: []gofig.Constraint{gofig.MutuallyExclusive("A", "B")},
+	})
+
+	if !errors.Is(errActual, gofig.ErrConstraint) {
+		t.Errorf("expected errors.Is(err, ErrConstraint), got: `%v`", errActual)
+	}
+	var constraintErr *gofig.ConstraintError
+	if !errors.As(errActual, &constraintErr) {
+		t.Fatalf("expected a *ConstraintError, got: `%v`", errActual)
+	}
+	if constraintErr.Rule != "mutuallyExclusive(A, B)" {
+		t.Errorf("expected: `%v`, got: `%v`", "mutuallyExclusive(A, B)", constraintErr.Rule)
+	}
+}
@@ -0,0 +1,303 @@
+package gofig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ippontech/gofig"
+)
+
+func Test_YamlSource_NestedAndFlatKeys(t *testing.T) {
+	path := writeTempFile(t, "config.yaml", `
+database:
+  host: localhost
+  port: 5432
+ENABLE_AUDIT: true
+timeout.seconds: 1.5
+`)
+
+	var hostId gofig.Id
+	var portId gofig.Id
+	var auditId gofig.Id
+	var timeoutId gofig.Id
+
+	ys, err := gofig.NewYamlSource(path)
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+
+	gf, err := gofig.InitWithSources([]gofig.InitOpt{
+		{Name: "DATABASE_HOST", Type: gofig.TypeString, Required: true, IdPtr: &hostId},
+		{Name: "DATABASE_PORT", Type: gofig.TypeInt, Required: true, IdPtr: &portId},
+		{Name: "ENABLE_AUDIT", Type: gofig.TypeBool, Required: true, IdPtr: &auditId},
+		{Name: "TIMEOUT_SECONDS", Type: gofig.TypeFloat, Required: true, IdPtr: &timeoutId},
+	}, ys)
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+
+	host, _ := gf.GetString(hostId)
+	port, _ := gf.GetInt(portId)
+	audit, _ := gf.GetBool(auditId)
+	timeout, _ := gf.GetFloat(timeoutId)
+	if host != "localhost" || port != 5432 || !audit || timeout != 1.5 {
+		t.Errorf("unexpected values: `%v`, `%v`, `%v`, `%v`", host, port, audit, timeout)
+	}
+}
+
+func Test_YamlSource_EnvOverridesFile(t *testing.T) {
+	t.Setenv("FOO", "from env")
+	path := writeTempFile(t, "config.yaml", "FOO: from file\n")
+
+	var fooId gofig.Id
+
+	initOpt := goodStringInitOpt
+	initOpt.IdPtr = &fooId
+
+	ys, err := gofig.NewYamlSource(path)
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+
+	gf, err := gofig.InitWithSources([]gofig.InitOpt{initOpt}, gofig.EnvSource{}, ys)
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+
+	foo, _ := gf.GetString(fooId)
+	if foo != "from env" {
+		t.Errorf("expected: `%v`, got: `%v`", "from env", foo)
+	}
+}
+
+func Test_YamlSource_Err_When_ValueIsWrongType(t *testing.T) {
+	path := writeTempFile(t, "config.yaml", "BAR: 1\nFOO: not an int\n")
+
+	var fooId gofig.Id
+
+	initOpt := goodIntInitOpt
+	initOpt.IdPtr = &fooId
+
+	ys, err := gofig.NewYamlSource(path)
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+
+	_, errActual := gofig.InitWithSources([]gofig.InitOpt{initOpt}, ys)
+
+	errExpected := gofig.ErrWrongTypeSetInSource(initOpt, "not an int", path+":2")
+	if errActual == nil || errActual.Error() != errExpected.Error() {
+		t.Error(ErrErrorsDoNotMatch(errExpected, errActual))
+	}
+}
+
+func Test_YamlSource_Err_When_KeysCollide(t *testing.T) {
+	path := writeTempFile(t, "config.yaml", "database:\n  host: a\ndatabase.host: b\n")
+
+	_, errActual := gofig.NewYamlSource(path)
+
+	errExpected := gofig.ErrDuplicateKeyInConfigFile(path, "database.host", 3, 2)
+	if errActual == nil || errActual.Error() != errExpected.Error() {
+		t.Error(ErrErrorsDoNotMatch(errExpected, errActual))
+	}
+}
+
+func Test_YamlSource_Err_When_NotMapping(t *testing.T) {
+	path := writeTempFile(t, "config.yaml", "- a\n- b\n")
+
+	_, errActual := gofig.NewYamlSource(path)
+
+	errExpected := gofig.ErrConfigFileNotMapping(path)
+	if errActual == nil || errActual.Error() != errExpected.Error() {
+		t.Error(ErrErrorsDoNotMatch(errExpected, errActual))
+	}
+}
+
+func Test_YamlSource_MergeKeys(t *testing.T) {
+	path := writeTempFile(t, "config.yaml", `
+base: &base
+  HOST: a
+  PORT: 1
+other: &other
+  PORT: 2
+  USER: b
+dev:
+  <<: *base
+  HOST: override
+prod:
+  <<: [*base, *other]
+  database:
+    <<: {NAME: merged, SCHEMA: public}
+    NAME: explicit
+`)
+
+	ys, err := gofig.NewYamlSource(path)
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+
+	expected := map[string]string{
+		"DEV_HOST":             "override",
+		"DEV_PORT":             "1",
+		"PROD_HOST":            "a",
+		"PROD_PORT":            "1",
+		"PROD_USER":            "b",
+		"PROD_DATABASE_NAME":   "explicit",
+		"PROD_DATABASE_SCHEMA": "public",
+	}
+	for name, val := range expected {
+		actual, found, _ := ys.Lookup(name)
+		if !found || actual != val {
+			t.Errorf("%s: expected: `%v`, got: `%v` (found: %v)", name, val, actual, found)
+		}
+	}
+
+	pairs, found, _ := ys.LookupStringMap("PROD_DATABASE")
+	if !found || pairs["NAME"] != "explicit" || pairs["SCHEMA"] != "public" || len(pairs) != 2 {
+		t.Errorf("expected the merged map of PROD_DATABASE, got: `%v` (found: %v)", pairs, found)
+	}
+}
+
+func Test_YamlSource_Err_When_MergeIsNotMapping(t *testing.T) {
+	path := writeTempFile(t, "config.yaml", "dev:\n  <<: [a, b]\n  HOST: a\n")
+
+	_, errActual := gofig.NewYamlSource(path)
+
+	errExpected := gofig.ErrParsingConfigFile(path, gofig.ErrYamlMergeNotMapping(2))
+	if errActual == nil || errActual.Error() != errExpected.Error() {
+		t.Error(ErrErrorsDoNotMatch(errExpected, errActual))
+	}
+}
+
+func Test_JsonSource_NestedKeys(t *testing.T) {
+	path := writeTempFile(t, "config.json", `{
+  "database": {
+    "host": "localhost",
+    "port": 5432
+  },
+  "ENABLE_AUDIT": true,
+  "TAGS": ["a", "b"]
+}`)
+
+	var hostId gofig.Id
+	var portId gofig.Id
+	var auditId gofig.Id
+	var tagsId gofig.Id
+
+	js, err := gofig.NewFileSource(path)
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+
+	gf, err := gofig.InitWithSources([]gofig.InitOpt{
+		{Name: "DATABASE_HOST", Type: gofig.TypeString, Required: true, IdPtr: &hostId},
+		{Name: "DATABASE_PORT", Type: gofig.TypeInt, Required: true, IdPtr: &portId},
+		{Name: "ENABLE_AUDIT", Type: gofig.TypeBool, Required: true, IdPtr: &auditId},
+		{Name: "TAGS", Type: gofig.TypeString, Required: true, IdPtr: &tagsId},
+	}, js)
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+
+	host, _ := gf.GetString(hostId)
+	port, _ := gf.GetInt(portId)
+	audit, _ := gf.GetBool(auditId)
+	tags, _ := gf.GetString(tagsId)
+	if host != "localhost" || port != 5432 || !audit || tags != `["a","b"]` {
+		t.Errorf("unexpected values: `%v`, `%v`, `%v`, `%v`", host, port, audit, tags)
+	}
+}
+
+func Test_JsonSource_Err_When_ValueIsWrongType(t *testing.T) {
+	path := writeTempFile(t, "config.json", "{\n  \"BAR\": 1,\n  \"FOO\": 1.5\n}")
+
+	var fooId gofig.Id
+
+	initOpt := goodIntInitOpt
+	initOpt.IdPtr = &fooId
+
+	js, err := gofig.NewFileSource(path)
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+
+	_, errActual := gofig.InitWithSources([]gofig.InitOpt{initOpt}, js)
+
+	errExpected := gofig.ErrWrongTypeSetInSource(initOpt, "1.5", path+":3")
+	if errActual == nil || errActual.Error() != errExpected.Error() {
+		t.Error(ErrErrorsDoNotMatch(errExpected, errActual))
+	}
+}
+
+func Test_TomlSource_TablesAndKeys(t *testing.T) {
+	path := writeTempFile(t, "config.toml", `
+ENABLE_AUDIT = true
+
+[database]
+host = "localhost"
+port = 5432
+`)
+
+	var hostId gofig.Id
+	var portId gofig.Id
+	var auditId gofig.Id
+
+	ts, err := gofig.NewFileSource(path)
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+
+	gf, err := gofig.InitWithSources([]gofig.InitOpt{
+		{Name: "DATABASE_HOST", Type: gofig.TypeString, Required: true, IdPtr: &hostId},
+		{Name: "DATABASE_PORT", Type: gofig.TypeInt, Required: true, IdPtr: &portId},
+		{Name: "ENABLE_AUDIT", Type: gofig.TypeBool, Required: true, IdPtr: &auditId},
+	}, ts)
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+
+	host, _ := gf.GetString(hostId)
+	port, _ := gf.GetInt(portId)
+	audit, _ := gf.GetBool(auditId)
+	if host != "localhost" || port != 5432 || !audit {
+		t.Errorf("unexpected values: `%v`, `%v`, `%v`", host, port, audit)
+	}
+}
+
+func Test_NewFileSource_Err_When_UnknownExtension(t *testing.T) {
+	path := writeTempFile(t, "config.ini", "FOO=bar\n")
+
+	_, errActual := gofig.NewFileSource(path)
+
+	errExpected := gofig.ErrUnknownConfigFileFormat(path)
+	if errActual == nil || errActual.Error() != errExpected.Error() {
+		t.Error(ErrErrorsDoNotMatch(errExpected, errActual))
+	}
+}
+
+// writeTempFile writes contents to a file with the given name in a temporary directory and returns its path
+func writeTempFile(t *testing.T, name string, contents string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func Test_FileSource_EmptyFileHasNoValues(t *testing.T) {
+	for _, name := range []string{"config.json", "config.yaml", "config.toml", "config.env"} {
+		for _, contents := range []string{"", " \n\t\n"} {
+			path := writeTempFile(t, name, contents)
+
+			fs, err := gofig.NewFileSource(path)
+			if err != nil {
+				t.Errorf("%s with %q: %v", name, contents, ErrExpectedNoError(err))
+				continue
+			}
+			if _, found, _ := fs.Lookup("FOO"); found {
+				t.Errorf("%s with %q: expected no values", name, contents)
+			}
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package gofig
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/ippontech/gofig"
+)
+
+func Test_FlagName_DerivedFromOptionName(t *testing.T) {
+	expected := "database-host"
+	actual := gofig.FlagName("DATABASE_HOST")
+	if actual != expected {
+		t.Errorf("expected: `%v`, got: `%v`", expected, actual)
+	}
+}
+
+func Test_FlagSource_FlagTakesPrecedenceOverEnv(t *testing.T) {
+	t.Setenv("DATABASE_HOST", "env-host")
+	t.Setenv("DATABASE_PORT", "5432")
+
+	var hostId gofig.Id
+	var portId gofig.Id
+
+	initOpts := []gofig.InitOpt{
+		{
+			Name:        "DATABASE_HOST",
+			Description: "The database host",
+			Type:        gofig.TypeString,
+			Required:    true,
+			IdPtr:       &hostId,
+		},
+		{
+			Name:        "DATABASE_PORT",
+			Description: "The database port",
+			Type:        gofig.TypeInt,
+			Required:    true,
+			IdPtr:       &portId,
+		},
+	}
+
+	fs, err := gofig.NewFlagSource(initOpts)
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+	if err := fs.Parse([]string{"--database-host", "flag-host"}); err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+
+	gf, err := gofig.InitWithSources(initOpts, fs, gofig.EnvSource{})
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+
+	host, _ := gf.GetString(hostId)
+	if host != "flag-host" {
+		t.Errorf("expected: `%v`, got: `%v`", "flag-host", host)
+	}
+	port, _ := gf.GetInt(portId)
+	if port != 5432 {
+		t.Errorf("expected: `%v`, got: `%v`", 5432, port)
+	}
+}
+
+func Test_FlagSource_BoolFlagWithoutValue(t *testing.T) {
+	var fooId gofig.Id
+
+	initOpt := goodBoolInitOpt
+	initOpt.IdPtr = &fooId
+
+	fs, err := gofig.NewFlagSource([]gofig.InitOpt{initOpt})
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+	if err := fs.Parse([]string{"--foo"}); err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+
+	gf, err := gofig.InitWithSources([]gofig.InitOpt{initOpt}, fs)
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+
+	foo, _ := gf.GetBool(fooId)
+	if !foo {
+		t.Errorf("expected: `%v`, got: `%v`", true, foo)
+	}
+}
+
+func Test_FlagSource_BoolFlagUsesVocabulary(t *testing.T) {
+	tests := []struct {
+		args     []string
+		bools    gofig.BoolVocabulary
+		expected bool
+	}{
+		{[]string{"--debug=yes"}, gofig.BoolVocabulary{}, true},
+		{[]string{"--debug=on"}, gofig.BoolVocabulary{}, true},
+		{[]string{"--debug=off"}, gofig.BoolVocabulary{}, false},
+		{[]string{"--debug=oui"}, gofig.BoolVocabulary{True: []string{"oui", "true"}, False: []string{"non"}}, true},
+		{[]string{"--debug=non"}, gofig.BoolVocabulary{True: []string{"oui", "true"}, False: []string{"non"}}, false},
+	}
+
+	for _, test := range tests {
+		var debugId gofig.Id
+		initOpts := []gofig.InitOpt{{Name: "DEBUG", Type: gofig.TypeBool, Default: false, IdPtr: &debugId}}
+
+		fs, err := gofig.NewFlagSource(initOpts)
+		if err != nil {
+			t.Fatal(ErrExpectedNoError(err))
+		}
+		if err := fs.Parse(test.args); err != nil {
+			t.Fatal(ErrExpectedNoError(err))
+		}
+
+		gf, err := gofig.InitWithSettings(initOpts, gofig.Settings{Sources: []gofig.Source{fs}, BoolVocabulary: test.bools})
+		if err != nil {
+			t.Fatal(ErrExpectedNoError(err))
+		}
+		if debug, _ := gf.GetBool(debugId); debug != test.expected {
+			t.Errorf("args: `%v`. expected: `%v`, got: `%v`", test.args, test.expected, debug)
+		}
+	}
+}
+
+func Test_FlagSource_Err_When_BoolFlagNotInVocabulary(t *testing.T) {
+	var debugId gofig.Id
+	initOpts := []gofig.InitOpt{{Name: "DEBUG", Type: gofig.TypeBool, Default: false, IdPtr: &debugId}}
+
+	fs, err := gofig.NewFlagSource(initOpts)
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+	if err := fs.Parse([]string{"--debug=maybe"}); err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+
+	_, errActual := gofig.InitWithSources(initOpts, fs)
+
+	errExpected := gofig.ErrWrongTypeSetInSource(initOpts[0], "maybe", "--debug")
+	if !errors.Is(errActual, gofig.ErrConversion) || !strings.Contains(errActual.Error(), errExpected.Error()) {
+		t.Error(ErrErrorsDoNotMatch(errExpected, errActual))
+	}
+}
+
+func Test_FlagSource_Err_When_FlagValueIsWrongType(t *testing.T) {
+	initOpt := goodIntInitOpt
+
+	fs, err := gofig.NewFlagSource([]gofig.InitOpt{initOpt})
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+	fs.FlagSet().SetOutput(io.Discard)
+
+	if err := fs.Parse([]string{"--foo", "not an int"}); err == nil {
+		t.Error(ErrExpectedError)
+	}
+}
+
+func Test_NewFlagSource_Err_When_OptionsMapToSameFlag(t *testing.T) {
+	_, errActual := gofig.NewFlagSource([]gofig.InitOpt{
+		{Name: "FOO_BAR", Type: gofig.TypeString, Required: true},
+		{Name: "foo_bar", Type: gofig.TypeString, Required: true},
+	})
+
+	errExpected := gofig.ErrDuplicateFlagName("foo-bar", "FOO_BAR", "foo_bar")
+	if errActual == nil || errActual.Error() != errExpected.Error() {
+		t.Error(ErrErrorsDoNotMatch(errExpected, errActual))
+	}
+}
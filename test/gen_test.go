@@ -0,0 +1,131 @@
+package gofig
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+	"testing"
+
+	"github.com/ippontech/gofig/gen"
+)
+
+func Test_Generate_ConfigPackage(t *testing.T) {
+	schema := gen.Schema{
+		Package: "settings",
+		Options: []gen.Option{
+			{Name: "DATABASE_HOST", Description: "The database host", Type: "string", Required: true},
+			{Name: "DATABASE_PORT", Description: "The database port", Type: "int", Default: "5432"},
+			{Name: "RATIO", Type: "float", Default: "1"},
+			{Name: "TIMEOUT", Type: "duration", Default: "1m30s"},
+			{Name: "HOSTS", Type: "[]string", Default: "a;b", Separator: ";"},
+		},
+	}
+
+	src, err := gen.Generate(schema, "schema.yaml")
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+	typeCheck(t, src)
+
+	expectedSnippets := []string{
+		"// Code generated by gofig-gen from schema.yaml. DO NOT EDIT.",
+		"package settings",
+		"DatabaseHostGfId gofig.Id",
+		"Type:        gofig.TypeString,",
+		"Default:     5432,",
+		"Default:     float64(1),",
+		"Default:     90 * time.Second,",
+		`Default:     []string{"a", "b"},`,
+		`Separator:   ";",`,
+		"func DatabaseHost() string {\n\tval, _ := GF.GetString(DatabaseHostGfId)",
+		"func Timeout() time.Duration {",
+	}
+	for _, snippet := range expectedSnippets {
+		if !strings.Contains(string(src), snippet) {
+			t.Errorf("expected generated code to contain `%s`, got:\n%s", snippet, src)
+		}
+	}
+}
+
+func Test_ReadSchema_Json(t *testing.T) {
+	path := writeTempFile(t, "schema.json", `{"package": "cfg", "options": [{"name": "PORT", "type": "int", "required": true}]}`)
+
+	schema, err := gen.ReadSchema(path)
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+	if schema.Package != "cfg" || len(schema.Options) != 1 || schema.Options[0].Name != "PORT" || !schema.Options[0].Required {
+		t.Errorf("unexpected schema: `%+v`", schema)
+	}
+}
+
+func Test_Generate_Err_When_DefaultIsWrongType(t *testing.T) {
+	opt := gen.Option{Name: "PORT", Type: "int", Default: "eighty"}
+
+	_, errActual := gen.Generate(gen.Schema{Options: []gen.Option{opt}}, "schema.yaml")
+	if errActual == nil || !strings.HasPrefix(errActual.Error(), "option `PORT` has an invalid default `eighty`") {
+		t.Errorf("expected an invalid default error, got: `%v`", errActual)
+	}
+}
+
+func Test_Generate_Err_When_UnknownType(t *testing.T) {
+	opt := gen.Option{Name: "PORT", Type: "int32", Required: true}
+
+	_, errActual := gen.Generate(gen.Schema{Options: []gen.Option{opt}}, "schema.yaml")
+	errExpected := gen.ErrUnknownOptionType(opt)
+	if errActual == nil || errActual.Error() != errExpected.Error() {
+		t.Error(ErrErrorsDoNotMatch(errExpected, errActual))
+	}
+}
+
+func Test_Generate_Err_When_NamesGiveSameIdentifier(t *testing.T) {
+	_, errActual := gen.Generate(gen.Schema{Options: []gen.Option{
+		{Name: "DATABASE_HOST", Type: "string", Required: true},
+		{Name: "database-host", Type: "string", Required: true},
+	}}, "schema.yaml")
+
+	errExpected := gen.ErrDuplicateIdentifier("DatabaseHost", "DATABASE_HOST", "database-host")
+	if errActual == nil || errActual.Error() != errExpected.Error() {
+		t.Error(ErrErrorsDoNotMatch(errExpected, errActual))
+	}
+}
+
+func Test_Generate_Err_When_NameGivesReservedIdentifier(t *testing.T) {
+	identsByName := map[string]string{
+		"LOAD":               "Load",
+		"load_with_settings": "LoadWithSettings",
+		"INIT_OPTS":          "InitOpts",
+		"PORT_GF_ID":         "PortGfId",
+	}
+
+	for name, ident := range identsByName {
+		opt := gen.Option{Name: name, Type: "int", Default: "1"}
+
+		_, errActual := gen.Generate(gen.Schema{Options: []gen.Option{
+			{Name: "PORT", Type: "int", Default: "80"},
+			opt,
+		}}, "schema.yaml")
+
+		errExpected := gen.ErrReservedIdentifier(opt, ident)
+		if errActual == nil || errActual.Error() != errExpected.Error() {
+			t.Error(ErrErrorsDoNotMatch(errExpected, errActual))
+		}
+	}
+}
+
+// typeCheck fails the test if the generated source doesn't compile
+func typeCheck(t *testing.T, src []byte) {
+	t.Helper()
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "config_gen.go", src, 0)
+	if err != nil {
+		t.Fatalf("generated code doesn't parse: %v\n%s", err, src)
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	if _, err := conf.Check(file.Name.Name, fset, []*ast.File{file}, nil); err != nil {
+		t.Fatalf("generated code doesn't compile: %v\n%s", err, src)
+	}
+}
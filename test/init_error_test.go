@@ -0,0 +1,98 @@
+package gofig
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ippontech/gofig"
+)
+
+func Test_Init_ReportsEveryProblem(t *testing.T) {
+	t.Setenv("PORT", "not an int")
+
+	var hostId, portId, userId, timeoutId gofig.Id
+
+	_, errActual := gofig.Init([]gofig.InitOpt{
+		{Name: "HOST", Type: gofig.TypeString, Required: true, IdPtr: &hostId},
+		{Name: "PORT", Type: gofig.TypeInt, Required: true, IdPtr: &portId},
+		{Name: "USER", Type: gofig.TypeString, Required: true, IdPtr: &userId},
+		{Name: "TIMEOUT", Type: gofig.TypeDuration, Required: false, Default: 30, IdPtr: &timeoutId},
+	})
+
+	var initErr *gofig.InitError
+	if !errors.As(errActual, &initErr) {
+		t.Fatalf("expected an *InitError, got: `%v`", errActual)
+	}
+
+	expectedNames := []string{"HOST", "PORT", "USER", "TIMEOUT"}
+	if len(initErr.Errors) != len(expectedNames) {
+		t.Fatalf("expected %d problems, got: `%v`", len(expectedNames), errActual)
+	}
+	for i, name := range expectedNames {
+		if initErr.Errors[i].Name != name {
+			t.Errorf("expected problem %d to be about `%s`, got: `%s`", i, name, initErr.Errors[i].Name)
+		}
+	}
+	if initErr.Errors[1].Source != "environment" {
+		t.Errorf("expected: `%v`, got: `%v`", "environment", initErr.Errors[1].Source)
+	}
+
+	expectedMsg := "4 config problems:" +
+		"\n\t- " + gofig.ErrRequiredConfigNotSet("HOST").Error() +
+		"\n\t- " + gofig.ErrWrongTypeSetInEnvironment(gofig.InitOpt{Name: "PORT", Type: gofig.TypeInt}, "not an int").Error() +
+		"\n\t- " + gofig.ErrRequiredConfigNotSet("USER").Error() +
+		"\n\t- " + gofig.ErrDefaultValueIsWrongTypeWhenNotRequired(gofig.InitOpt{Name: "TIMEOUT", Type: gofig.TypeDuration, Default: 30}).Error()
+	if errActual.Error() != expectedMsg {
+		t.Errorf("expected: `%v`, got: `%v`", expectedMsg, errActual)
+	}
+}
+
+func Test_Init_ErrorsIsFindsWrappedError(t *testing.T) {
+	t.Setenv("WORKERS", "3")
+
+	var workersId, hostId gofig.Id
+
+	errOdd := errors.New("must be even")
+	even := gofig.ValidatorFunc("even", func(val any) error {
+		if val.(int)%2 != 0 {
+			return errOdd
+		}
+		return nil
+	})
+
+	_, errActual := gofig.Init([]gofig.InitOpt{
+		{Name: "HOST", Type: gofig.TypeString, Required: true, IdPtr: &hostId},
+		{Name: "WORKERS", Type: gofig.TypeInt, Required: true, IdPtr: &workersId, Validators: []gofig.Validator{even}},
+	})
+
+	if !errors.Is(errActual, errOdd) {
+		t.Errorf("expected errors.Is to find `%v` in `%v`", errOdd, errActual)
+	}
+
+	var optErr *gofig.OptionError
+	if !errors.As(errActual, &optErr) || optErr.Name != "HOST" {
+		t.Errorf("expected errors.As to find the *OptionError for `HOST` in `%v`", errActual)
+	}
+}
+
+func Test_Init_ConstraintsStillCheckedWhenOtherOptionsFail(t *testing.T) {
+	var aId, bId, cId gofig.Id
+
+	_, errActual := gofig.InitWithSettings([]gofig.InitOpt{
+		{Name: "A", Type: gofig.TypeString, Default: "", IdPtr: &aId},
+		{Name: "B", Type: gofig.TypeString, Default: "", IdPtr: &bId},
+		{Name: "C", Type: gofig.TypeInt, Required: true, IdPtr: &cId},
+	}, gofig.Settings{
+		Sources:     []gofig.Source{gofig.MapSource{"A": "a", "B": "b", "C": "c"}},
+		Constraints: []gofig.Constraint{gofig.MutuallyExclusive("A", "B"), gofig.Requires("C", "A")},
+	})
+
+	var initErr *gofig.InitError
+	if !errors.As(errActual, &initErr) {
+		t.Fatalf("expected an *InitError, got: `%v`", errActual)
+	}
+	// the conversion error for C and the failed constraint between A and B. The constraint on C is skipped.
+	if len(initErr.Errors) != 2 || initErr.Errors[0].Name != "C" || initErr.Errors[1].Name != "A, B" {
+		t.Errorf("unexpected problems: `%v`", errActual)
+	}
+}
@@ -0,0 +1,68 @@
+package gofig
+
+import (
+	"testing"
+
+	"github.com/ippontech/gofig"
+)
+
+func Test_Get_Err_When_IdIsFromAnotherGofig(t *testing.T) {
+	var firstId, secondId gofig.Id
+
+	_, err := gofig.InitWithSources([]gofig.InitOpt{
+		{Name: "HOST", Type: gofig.TypeString, Required: true, IdPtr: &firstId},
+	}, gofig.MapSource{"HOST": "first"})
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+	second, err := gofig.InitWithSources([]gofig.InitOpt{
+		{Name: "HOST", Type: gofig.TypeString, Required: true, IdPtr: &secondId},
+	}, gofig.MapSource{"HOST": "second"})
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+
+	_, errActual := second.GetString(firstId)
+	errExpected := gofig.ErrForeignId
+	if errActual != errExpected {
+		t.Error(ErrErrorsDoNotMatch(errExpected, errActual))
+	}
+
+	host, err := second.GetString(secondId)
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+	if host != "second" {
+		t.Errorf("expected: `%v`, got: `%v`", "second", host)
+	}
+}
+
+func Test_Get_Err_When_IdWasInitializedAgain(t *testing.T) {
+	var hostId gofig.Id
+	initOpts := []gofig.InitOpt{
+		{Name: "HOST", Type: gofig.TypeString, Required: true, IdPtr: &hostId},
+	}
+
+	first, err := gofig.InitWithSources(initOpts, gofig.MapSource{"HOST": "first"})
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+	second, err := gofig.InitWithSources(initOpts, gofig.MapSource{"HOST": "second"})
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+
+	_, errActual := first.Get(hostId)
+	errExpected := gofig.ErrForeignId
+	if errActual != errExpected {
+		t.Error(ErrErrorsDoNotMatch(errExpected, errActual))
+	}
+
+	host, err := second.Get(hostId)
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+	if host != "second" {
+		t.Errorf("expected: `%v`, got: `%v`", "second", host)
+	}
+}
@@ -0,0 +1,113 @@
+package gofig
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ippontech/gofig"
+)
+
+func Test_Value_ReturnsTypedValues(t *testing.T) {
+	var portKey gofig.Key[int]
+	var timeoutKey gofig.Key[time.Duration]
+	var hostsKey gofig.Key[[]string]
+
+	gf, err := gofig.InitWithSources([]gofig.InitOpt{
+		{Name: "PORT", Type: gofig.TypeInt, Required: true, IdPtr: portKey.IdPtr()},
+		{Name: "TIMEOUT", Type: gofig.TypeDuration, Required: true, IdPtr: timeoutKey.IdPtr()},
+		{Name: "HOSTS", Type: gofig.TypeStringSlice, Required: true, IdPtr: hostsKey.IdPtr()},
+	}, gofig.MapSource{"PORT": "8080", "TIMEOUT": "2s", "HOSTS": "a,b"})
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+
+	port, err := gofig.Value(&gf, portKey)
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+	if port != 8080 {
+		t.Errorf("expected: `%v`, got: `%v`", 8080, port)
+	}
+
+	timeout, _ := gofig.Value(&gf, timeoutKey)
+	if timeout != 2*time.Second {
+		t.Errorf("expected: `%v`, got: `%v`", 2*time.Second, timeout)
+	}
+
+	hosts, _ := gofig.Value(&gf, hostsKey)
+	hosts[0] = "changed"
+	hosts, _ = gofig.Value(&gf, hostsKey)
+	if !reflect.DeepEqual(hosts, []string{"a", "b"}) {
+		t.Errorf("expected: `%v`, got: `%v`", []string{"a", "b"}, hosts)
+	}
+}
+
+func Test_Key_IdWorksWithGetFamily(t *testing.T) {
+	var debugKey gofig.Key[bool]
+
+	gf, err := gofig.InitWithSources([]gofig.InitOpt{
+		{Name: "DEBUG", Type: gofig.TypeBool, Required: true, IdPtr: debugKey.IdPtr()},
+	}, gofig.MapSource{"DEBUG": "true"})
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+
+	debug, err := gf.GetBool(debugKey.Id())
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+	if !debug {
+		t.Errorf("expected: `%v`, got: `%v`", true, debug)
+	}
+}
+
+func Test_Init_Err_When_KeyTypeDoesNotMatchType(t *testing.T) {
+	var portKey gofig.Key[string]
+
+	initOpt := gofig.InitOpt{Name: "PORT", Type: gofig.TypeInt, Required: true, IdPtr: portKey.IdPtr()}
+
+	_, errActual := gofig.InitWithSources([]gofig.InitOpt{initOpt}, gofig.MapSource{"PORT": "8080"})
+
+	errExpected := gofig.ErrKeyTypeMismatch(initOpt)
+	if errActual == nil || errActual.Error() != errExpected.Error() {
+		t.Error(ErrErrorsDoNotMatch(errExpected, errActual))
+	}
+}
+
+func Test_Value_Err_When_NotInitialized(t *testing.T) {
+	var portKey gofig.Key[int]
+	var gf gofig.Gofig
+
+	_, errActual := gofig.Value(&gf, portKey)
+	errExpected := gofig.ErrNotInitialized
+	if errActual != errExpected {
+		t.Error(ErrErrorsDoNotMatch(errExpected, errActual))
+	}
+}
+
+func Test_Value_Err_When_KeyIsFromAnotherGofig(t *testing.T) {
+	var portKey, otherKey gofig.Key[int]
+
+	first, err := gofig.InitWithSources([]gofig.InitOpt{
+		{Name: "PORT", Type: gofig.TypeInt, Required: true, IdPtr: portKey.IdPtr()},
+	}, gofig.MapSource{"PORT": "8080"})
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+	_, err = gofig.InitWithSources([]gofig.InitOpt{
+		{Name: "PORT", Type: gofig.TypeInt, Required: true, IdPtr: otherKey.IdPtr()},
+	}, gofig.MapSource{"PORT": "9090"})
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+
+	_, errActual := gofig.Value(&first, otherKey)
+	if !errors.Is(errActual, gofig.ErrForeignId) {
+		t.Error(ErrErrorsDoNotMatch(gofig.ErrForeignId, errActual))
+	}
+	if _, errActual := gofig.Value(&first, gofig.Key[int]{}); !errors.Is(errActual, gofig.ErrInvalidId) {
+		t.Error(ErrErrorsDoNotMatch(gofig.ErrInvalidId, errActual))
+	}
+}
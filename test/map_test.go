@@ -0,0 +1,104 @@
+package gofig
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ippontech/gofig"
+)
+
+func Test_Init_StringMap_FromEnv(t *testing.T) {
+	t.Setenv("HEADERS", "X-Tenant = acme, X-Trace=a\\,b=c")
+
+	var headersId gofig.Id
+
+	gf, err := gofig.Init([]gofig.InitOpt{
+		{Name: "HEADERS", Type: gofig.TypeStringMap, Required: true, IdPtr: &headersId},
+	})
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+
+	expected := map[string]string{"X-Tenant": "acme", "X-Trace": "a,b=c"}
+	actual, err := gf.GetStringMap(headersId)
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected: `%v`, got: `%v`", expected, actual)
+	}
+}
+
+func Test_Init_Err_When_StringMapPairHasNoEquals(t *testing.T) {
+	t.Setenv("HEADERS", "a=1,b")
+
+	var headersId gofig.Id
+
+	initOpt := gofig.InitOpt{Name: "HEADERS", Type: gofig.TypeStringMap, Required: true, IdPtr: &headersId}
+
+	_, errActual := gofig.Init([]gofig.InitOpt{initOpt})
+
+	errExpected := gofig.ErrWrongTypeSetInEnvironment(initOpt, "b")
+	if errActual == nil || errActual.Error() != errExpected.Error() {
+		t.Error(ErrErrorsDoNotMatch(errExpected, errActual))
+	}
+}
+
+func Test_JsonSource_ObjectForStringMap(t *testing.T) {
+	path := writeTempFile(t, "config.json", `{"tenant_limits": {"acme": 10, "globex": 20}}`)
+
+	var limitsId gofig.Id
+
+	js, err := gofig.NewJsonSource(path)
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+
+	gf, err := gofig.InitWithSources([]gofig.InitOpt{
+		{Name: "TENANT_LIMITS", Type: gofig.TypeStringMap, Required: true, IdPtr: &limitsId},
+	}, js)
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+
+	expected := map[string]string{"acme": "10", "globex": "20"}
+	actual, _ := gf.GetStringMap(limitsId)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected: `%v`, got: `%v`", expected, actual)
+	}
+}
+
+func Test_GetStringMap_ReturnsCopy(t *testing.T) {
+	path := writeTempFile(t, "config.yaml", "headers:\n  a: 1\n")
+
+	var headersId gofig.Id
+
+	ys, err := gofig.NewYamlSource(path)
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+
+	gf, err := gofig.InitWithSources([]gofig.InitOpt{
+		{Name: "HEADERS", Type: gofig.TypeStringMap, Required: true, IdPtr: &headersId},
+	}, ys)
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+
+	first, _ := gf.GetStringMap(headersId)
+	first["a"] = "changed"
+	first["b"] = "added"
+
+	second, _ := gf.GetStringMap(headersId)
+	if !reflect.DeepEqual(second, map[string]string{"a": "1"}) {
+		t.Errorf("changing a map returned by GetStringMap changed the value in Gofig: `%v`", second)
+	}
+
+	third, _ := gf.Get(headersId)
+	third.(map[string]string)["a"] = "changed"
+
+	fourth, _ := gf.GetStringMap(headersId)
+	if fourth["a"] != "1" {
+		t.Errorf("changing a map returned by Get changed the value in Gofig: `%v`", fourth)
+	}
+}
@@ -0,0 +1,156 @@
+package gofig
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/ippontech/gofig"
+)
+
+func Test_IdByName_ReturnsIdOfOption(t *testing.T) {
+	var hostId, portId gofig.Id
+
+	gf, err := gofig.InitWithSources([]gofig.InitOpt{
+		{Name: "HOST", Type: gofig.TypeString, Required: true, IdPtr: &hostId},
+		{Name: "PORT", Type: gofig.TypeInt, Required: true, IdPtr: &portId},
+	}, gofig.MapSource{"HOST": "localhost", "PORT": "8080"})
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+
+	id, ok := gf.IdByName("PORT")
+	if !ok {
+		t.Fatal("expected an Id for `PORT`")
+	}
+	port, err := gf.GetInt(id)
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+	if port != 8080 {
+		t.Errorf("expected: `%v`, got: `%v`", 8080, port)
+	}
+
+	if _, ok := gf.IdByName("NOPE"); ok {
+		t.Error("expected no Id for `NOPE`")
+	}
+}
+
+func Test_GetByName(t *testing.T) {
+	var hostId gofig.Id
+
+	gf, err := gofig.InitWithSources([]gofig.InitOpt{
+		{Name: "HOST", Type: gofig.TypeString, Required: true, IdPtr: &hostId},
+	}, gofig.MapSource{"HOST": "localhost"})
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+
+	host, err := gf.GetByName("HOST")
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+	if host != "localhost" {
+		t.Errorf("expected: `%v`, got: `%v`", "localhost", host)
+	}
+
+	_, errActual := gf.GetByName("NOPE")
+	errExpected := gofig.ErrUnknownConfigName("NOPE")
+	if errActual == nil || errActual.Error() != errExpected.Error() {
+		t.Error(ErrErrorsDoNotMatch(errExpected, errActual))
+	}
+}
+
+func Test_GetByName_Err_When_GofigNotInitialized(t *testing.T) {
+	gf := gofig.Gofig{}
+
+	_, errActual := gf.GetByName("HOST")
+	errExpected := gofig.ErrNotInitialized
+	if errActual != errExpected {
+		t.Error(ErrErrorsDoNotMatch(errExpected, errActual))
+	}
+}
+
+func Test_Init_Err_When_NameIsDuplicated(t *testing.T) {
+	var firstId, secondId gofig.Id
+
+	_, errActual := gofig.InitWithSources([]gofig.InitOpt{
+		{Name: "HOST", Type: gofig.TypeString, Required: true, IdPtr: &firstId},
+		{Name: "HOST", Type: gofig.TypeString, Required: true, IdPtr: &secondId},
+	}, gofig.MapSource{"HOST": "localhost"})
+
+	errExpected := gofig.ErrDuplicateConfigName("HOST", 0, 1)
+	if errActual == nil || errActual.Error() != errExpected.Error() {
+		t.Error(ErrErrorsDoNotMatch(errExpected, errActual))
+	}
+
+	var dupErr *gofig.DuplicateNameError
+	if !errors.As(errActual, &dupErr) || !errors.Is(errActual, gofig.ErrDuplicateName) {
+		t.Fatalf("expected a *DuplicateNameError, got: `%v`", errActual)
+	}
+	if dupErr.Name != "HOST" || !reflect.DeepEqual(dupErr.Indexes, []int{0, 1}) {
+		t.Errorf("unexpected error fields: `%+v`", dupErr)
+	}
+}
+
+func Test_Init_Err_When_IdPtrIsNil(t *testing.T) {
+	initOpt := gofig.InitOpt{Name: "HOST", Type: gofig.TypeString, Required: true}
+
+	_, errActual := gofig.InitWithSources([]gofig.InitOpt{initOpt}, gofig.MapSource{"HOST": "localhost"})
+
+	errExpected := gofig.ErrNilIdPtr(initOpt)
+	if errActual == nil || errActual.Error() != errExpected.Error() {
+		t.Error(ErrErrorsDoNotMatch(errExpected, errActual))
+	}
+
+	var nilErr *gofig.NilIdPtrError
+	if !errors.As(errActual, &nilErr) || !errors.Is(errActual, gofig.ErrMissingIdPtr) {
+		t.Fatalf("expected a *NilIdPtrError, got: `%v`", errActual)
+	}
+	if nilErr.Name != "HOST" {
+		t.Errorf("expected: `%v`, got: `%v`", "HOST", nilErr.Name)
+	}
+}
+
+func Test_Init_Err_When_IdPtrIsReused(t *testing.T) {
+	var sharedId, portId gofig.Id
+
+	_, errActual := gofig.InitWithSources([]gofig.InitOpt{
+		{Name: "HOST", Type: gofig.TypeString, Required: true, IdPtr: &sharedId},
+		{Name: "PORT", Type: gofig.TypeInt, Required: true, IdPtr: &portId},
+		{Name: "USER", Type: gofig.TypeString, Required: true, IdPtr: &sharedId},
+	}, gofig.MapSource{"HOST": "localhost", "PORT": "8080", "USER": "admin"})
+
+	errExpected := gofig.ErrReusedIdPtr("HOST", "USER")
+	if errActual == nil || errActual.Error() != errExpected.Error() {
+		t.Error(ErrErrorsDoNotMatch(errExpected, errActual))
+	}
+
+	var reusedErr *gofig.ReusedIdPtrError
+	if !errors.As(errActual, &reusedErr) || !errors.Is(errActual, gofig.ErrSharedIdPtr) {
+		t.Fatalf("expected a *ReusedIdPtrError, got: `%v`", errActual)
+	}
+	if !reflect.DeepEqual(reusedErr.Names, []string{"HOST", "USER"}) {
+		t.Errorf("expected: `%v`, got: `%v`", []string{"HOST", "USER"}, reusedErr.Names)
+	}
+}
+
+func Test_Init_ReportsEveryDefinitionProblem(t *testing.T) {
+	var hostId, otherHostId, sharedId gofig.Id
+
+	_, errActual := gofig.InitWithSources([]gofig.InitOpt{
+		{Name: "HOST", Type: gofig.TypeString, Required: true, IdPtr: &hostId},
+		{Name: "PORT", Type: gofig.TypeInt, Required: true},
+		{Name: "HOST", Type: gofig.TypeString, Required: true, IdPtr: &otherHostId},
+		{Name: "A", Type: gofig.TypeString, Required: true, IdPtr: &sharedId},
+		{Name: "B", Type: gofig.TypeString, Required: true, IdPtr: &sharedId},
+	}, gofig.MapSource{"HOST": "localhost", "PORT": "8080", "A": "a", "B": "b"})
+
+	expectedMsg := "3 config problems:" +
+		"\n\t- " + gofig.ErrNilIdPtr(gofig.InitOpt{Name: "PORT"}).Error() +
+		"\n\t- " + gofig.ErrDuplicateConfigName("HOST", 0, 2).Error() +
+		"\n\t- " + gofig.ErrReusedIdPtr("A", "B").Error()
+	if errActual == nil || errActual.Error() != expectedMsg {
+		t.Errorf("expected: `%v`, got: `%v`", expectedMsg, errActual)
+	}
+}
@@ -0,0 +1,102 @@
+package gofig
+
+import (
+	"testing"
+
+	"github.com/ippontech/gofig"
+)
+
+// otherSource is a Source that doesn't report its kind
+type otherSource struct{}
+
+func (otherSource) Lookup(name string) (string, bool, string) {
+	if name == "REGION" {
+		return "eu-west-1", true, "vault"
+	}
+	return "", false, ""
+}
+
+func Test_Origin_RecordsWhereEachValueCameFrom(t *testing.T) {
+	t.Setenv("HOST", "env-host")
+	path := writeTempFile(t, "config.yaml", "user: admin\nports: [80, 443]\n")
+
+	var hostId, userId, portsId, timeoutId, nameId, regionId, debugId gofig.Id
+
+	initOpts := []gofig.InitOpt{
+		{Name: "HOST", Type: gofig.TypeString, Required: true, IdPtr: &hostId},
+		{Name: "USER", Type: gofig.TypeString, Required: true, IdPtr: &userId},
+		{Name: "PORTS", Type: gofig.TypeIntSlice, Required: true, IdPtr: &portsId},
+		{Name: "TIMEOUT", Type: gofig.TypeInt, Default: 30, IdPtr: &timeoutId},
+		{Name: "NAME", Type: gofig.TypeString, Required: true, IdPtr: &nameId},
+		{Name: "REGION", Type: gofig.TypeString, Required: true, IdPtr: &regionId},
+		{Name: "DEBUG", Type: gofig.TypeBool, Required: true, IdPtr: &debugId},
+	}
+
+	ys, err := gofig.NewYamlSource(path)
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+	fs, err := gofig.NewFlagSource(initOpts)
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+	if err := fs.Parse([]string{"--debug"}); err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+
+	gf, err := gofig.InitWithSources(initOpts, fs, gofig.EnvSource{}, ys, gofig.MapSource{"NAME": "app"}, otherSource{})
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+
+	expected := map[*gofig.Id]gofig.Origin{
+		&hostId:    {Kind: gofig.SourceEnv, Label: "environment", Raw: "env-host"},
+		&userId:    {Kind: gofig.SourceFile, Label: path + ":1", Raw: "admin"},
+		&portsId:   {Kind: gofig.SourceFile, Label: path + ":2", Raw: "80,443"},
+		&timeoutId: {Kind: gofig.SourceDefault, Raw: "30"},
+		&nameId:    {Kind: gofig.SourceMap, Label: "map", Raw: "app"},
+		&regionId:  {Kind: gofig.SourceOther, Label: "vault", Raw: "eu-west-1"},
+		&debugId:   {Kind: gofig.SourceFlag, Label: "--debug", Raw: "true"},
+	}
+	for idPtr, expectedOrigin := range expected {
+		actual, err := gf.Origin(*idPtr)
+		if err != nil {
+			t.Fatal(ErrExpectedNoError(err))
+		}
+		if actual != expectedOrigin {
+			t.Errorf("expected: `%+v`, got: `%+v`", expectedOrigin, actual)
+		}
+	}
+}
+
+func Test_Explain_ListsValuesAndOrigins(t *testing.T) {
+	var hostId, portId gofig.Id
+
+	gf, err := gofig.InitWithSources([]gofig.InitOpt{
+		{Name: "HOST", Type: gofig.TypeString, Required: true, IdPtr: &hostId},
+		{Name: "PORT", Type: gofig.TypeInt, Default: 5432, IdPtr: &portId},
+	}, gofig.MapSource{"HOST": "localhost"})
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+
+	expected := "HOST=localhost (map `map`)\nPORT=5432 (default)\n"
+	actual, err := gf.Explain()
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+	if actual != expected {
+		t.Errorf("expected: `%v`, got: `%v`", expected, actual)
+	}
+}
+
+func Test_Origin_Err_When_GofigNotInitialized(t *testing.T) {
+	var fooId gofig.Id
+	gf := gofig.Gofig{}
+
+	_, errActual := gf.Origin(fooId)
+	errExpected := gofig.ErrNotInitialized
+	if errActual != errExpected {
+		t.Error(ErrErrorsDoNotMatch(errExpected, errActual))
+	}
+}
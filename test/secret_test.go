@@ -0,0 +1,202 @@
+package gofig
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/ippontech/gofig"
+	"github.com/ippontech/gofig/gen"
+)
+
+func Test_Secret_MaskedWhenPrinted(t *testing.T) {
+	var passwordId gofig.Id
+
+	gf, err := gofig.InitWithSources([]gofig.InitOpt{
+		{Name: "PASSWORD", Type: gofig.TypeString, Required: true, Secret: true, IdPtr: &passwordId},
+	}, gofig.MapSource{"PASSWORD": "hunter2"})
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+
+	val, err := gf.GetByName("PASSWORD")
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+	for _, format := range []string{"%v", "%+v", "%#v", "%s", "%q"} {
+		if printed := fmt.Sprintf(format, val); strings.Contains(printed, "hunter2") {
+			t.Errorf("`%s` printed the secret: `%s`", format, printed)
+		}
+	}
+	if data, _ := json.Marshal(map[string]any{"PASSWORD": val}); strings.Contains(string(data), "hunter2") {
+		t.Errorf("JSON has the secret: `%s`", data)
+	}
+
+	secret, err := gf.GetSecret(passwordId)
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+	if secret.Reveal() != "hunter2" {
+		t.Errorf("expected: `%v`, got: `%v`", "hunter2", secret.Reveal())
+	}
+	if password, _ := gf.GetString(passwordId); password != "hunter2" {
+		t.Errorf("expected: `%v`, got: `%v`", "hunter2", password)
+	}
+}
+
+func Test_Secret_MaskedInExplainAndOrigin(t *testing.T) {
+	var passwordId gofig.Id
+
+	gf, err := gofig.InitWithSources([]gofig.InitOpt{
+		{Name: "PASSWORD", Type: gofig.TypeString, Required: true, Secret: true, IdPtr: &passwordId},
+	}, gofig.MapSource{"PASSWORD": "hunter2"})
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+
+	explained, _ := gf.Explain()
+	origin, _ := gf.Origin(passwordId)
+	if strings.Contains(explained, "hunter2") || origin.Raw == "hunter2" {
+		t.Errorf("secret leaked: `%s`, `%+v`", explained, origin)
+	}
+}
+
+func Test_Secret_MaskedInErrors(t *testing.T) {
+	var pinId, tokenId gofig.Id
+
+	_, errActual := gofig.InitWithSources([]gofig.InitOpt{
+		{Name: "PIN", Type: gofig.TypeInt, Required: true, Secret: true, IdPtr: &pinId},
+		{Name: "TOKEN", Type: gofig.TypeString, Required: true, Secret: true, IdPtr: &tokenId, Validators: []gofig.Validator{gofig.OneOf("abc")}},
+	}, gofig.MapSource{"PIN": "12a4", "TOKEN": "s3cr3t"})
+
+	if errActual == nil {
+		t.Fatal(ErrExpectedError)
+	}
+	if strings.Contains(errActual.Error(), "12a4") || strings.Contains(errActual.Error(), "s3cr3t") {
+		t.Errorf("secret leaked in error: `%v`", errActual)
+	}
+}
+
+func Test_Secret_MaskedInDocs(t *testing.T) {
+	initOpts := []gofig.InitOpt{
+		{Name: "PASSWORD", Description: "The password", Type: gofig.TypeString, Default: "changeme", Secret: true},
+	}
+
+	for _, format := range []gofig.DocFormat{gofig.DocText, gofig.DocMarkdown, gofig.DocJSON, gofig.DocMan} {
+		docs, err := gofig.DocStringFormat(initOpts, format)
+		if err != nil {
+			t.Fatal(ErrExpectedNoError(err))
+		}
+		if strings.Contains(docs, "changeme") {
+			t.Errorf("format %d leaked the secret default: `%s`", format, docs)
+		}
+	}
+}
+
+func Test_Bind_SecretTag(t *testing.T) {
+	var cfg struct {
+		Password string `gofig:"PASSWORD" required:"true" secret:"true"`
+	}
+
+	err := gofig.BindWithSettings(&cfg, gofig.Settings{Sources: []gofig.Source{gofig.MapSource{"PASSWORD": "hunter2"}}})
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+	if cfg.Password != "hunter2" {
+		t.Errorf("expected: `%v`, got: `%v`", "hunter2", cfg.Password)
+	}
+
+	initOpts, _ := gofig.StructInitOpts(&cfg)
+	if !initOpts[0].Secret {
+		t.Error("expected the secret tag to set Secret")
+	}
+}
+
+func Test_Secret_MaskedInInvalidDefaults(t *testing.T) {
+	var cfg struct {
+		Pw int `gofig:"PW" secret:"true" default:"hunter2"`
+	}
+
+	errActual := gofig.Bind(&cfg)
+	if errActual == nil {
+		t.Fatal(ErrExpectedError)
+	}
+	if strings.Contains(errActual.Error(), "hunter2") {
+		t.Errorf("secret leaked in Bind error: `%v`", errActual)
+	}
+
+	_, errActual = gen.Generate(gen.Schema{Options: []gen.Option{
+		{Name: "PW", Type: "int", Default: "hunter2", Secret: true},
+	}}, "schema.yaml")
+	if errActual == nil {
+		t.Fatal(ErrExpectedError)
+	}
+	if strings.Contains(errActual.Error(), "hunter2") {
+		t.Errorf("secret leaked in Generate error: `%v`", errActual)
+	}
+}
+
+func Test_Generate_SecretDefault(t *testing.T) {
+	src, err := gen.Generate(gen.Schema{Options: []gen.Option{
+		{Name: "PW", Type: "string", Default: "changeme", Secret: true},
+	}}, "schema.yaml")
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+	if !strings.Contains(string(src), `Default:     "changeme",`) {
+		t.Errorf("expected the default in the generated code, got:\n%s", src)
+	}
+}
+
+func Test_Secret_MaskedInFlagErrors(t *testing.T) {
+	for _, initOpt := range []gofig.InitOpt{
+		{Name: "PIN", Type: gofig.TypeInt, Required: true, Secret: true},
+		{Name: "PIN", Type: gofig.TypeFloat, Required: true, Secret: true},
+		{Name: "PIN", Type: gofig.TypeDuration, Required: true, Secret: true},
+	} {
+		var pinId gofig.Id
+		initOpt.IdPtr = &pinId
+		initOpts := []gofig.InitOpt{initOpt}
+
+		fs, err := gofig.NewFlagSource(initOpts)
+		if err != nil {
+			t.Fatal(ErrExpectedNoError(err))
+		}
+		fs.FlagSet().SetOutput(io.Discard)
+		if err := fs.Parse([]string{"--pin", "hunter2"}); err != nil {
+			t.Fatalf("expected Parse to leave the value to Init, got: `%v`", err)
+		}
+
+		_, errActual := gofig.InitWithSources(initOpts, fs)
+		if !errors.Is(errActual, gofig.ErrConversion) {
+			t.Errorf("expected a conversion error, got: `%v`", errActual)
+		}
+		if errActual != nil && strings.Contains(errActual.Error(), "hunter2") {
+			t.Errorf("secret leaked in Init error: `%v`", errActual)
+		}
+	}
+}
+
+func Test_Secret_FlagValueConverted(t *testing.T) {
+	var pinId gofig.Id
+	initOpts := []gofig.InitOpt{{Name: "PIN", Type: gofig.TypeInt, Required: true, Secret: true, IdPtr: &pinId}}
+
+	fs, err := gofig.NewFlagSource(initOpts)
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+	if err := fs.Parse([]string{"--pin", "1234"}); err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+
+	gf, err := gofig.InitWithSources(initOpts, fs)
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+	if pin, _ := gf.GetInt(pinId); pin != 1234 {
+		t.Errorf("expected: `%v`, got: `%v`", 1234, pin)
+	}
+}
@@ -0,0 +1,147 @@
+package gofig
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ippontech/gofig"
+)
+
+func Test_Init_StringSlice_SplitAndTrimmed(t *testing.T) {
+	t.Setenv("FOO", " a, b ,c ")
+
+	var fooId gofig.Id
+
+	gf, err := gofig.Init([]gofig.InitOpt{
+		{Name: "FOO", Type: gofig.TypeStringSlice, Required: true, IdPtr: &fooId},
+	})
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+
+	expected := []string{"a", "b", "c"}
+	actual, err := gf.GetStringSlice(fooId)
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected: `%v`, got: `%v`", expected, actual)
+	}
+}
+
+func Test_Init_StringSlice_CustomSeparatorAndEscaping(t *testing.T) {
+	t.Setenv("FOO", `a;b\;c; d `)
+
+	var fooId gofig.Id
+
+	gf, err := gofig.Init([]gofig.InitOpt{
+		{Name: "FOO", Type: gofig.TypeStringSlice, Required: true, Separator: ";", NoTrim: true, IdPtr: &fooId},
+	})
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+
+	expected := []string{"a", "b;c", " d "}
+	actual, _ := gf.GetStringSlice(fooId)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected: `%v`, got: `%v`", expected, actual)
+	}
+}
+
+func Test_Init_Err_When_EnvVarCannotBeConvertedToIntSlice(t *testing.T) {
+	t.Setenv("FOO", "1,2,three")
+
+	var fooId gofig.Id
+
+	initOpt := gofig.InitOpt{Name: "FOO", Type: gofig.TypeIntSlice, Required: true, IdPtr: &fooId}
+
+	_, errActual := gofig.Init([]gofig.InitOpt{initOpt})
+
+	errExpected := gofig.ErrWrongTypeSetInEnvironment(initOpt, "three")
+	if errActual == nil || errActual.Error() != errExpected.Error() {
+		t.Error(ErrErrorsDoNotMatch(errExpected, errActual))
+	}
+}
+
+func Test_YamlSource_NativeListForIntSlice(t *testing.T) {
+	path := writeTempFile(t, "config.yaml", "PORTS: [80, 443]\nRATIOS:\n  - 0.5\n  - 1.5\n")
+
+	var portsId gofig.Id
+	var ratiosId gofig.Id
+
+	ys, err := gofig.NewYamlSource(path)
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+
+	gf, err := gofig.InitWithSources([]gofig.InitOpt{
+		{Name: "PORTS", Type: gofig.TypeIntSlice, Required: true, IdPtr: &portsId},
+		{Name: "RATIOS", Type: gofig.TypeFloatSlice, Required: true, IdPtr: &ratiosId},
+	}, ys)
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+
+	ports, _ := gf.GetIntSlice(portsId)
+	if !reflect.DeepEqual(ports, []int{80, 443}) {
+		t.Errorf("expected: `%v`, got: `%v`", []int{80, 443}, ports)
+	}
+	ratios, _ := gf.GetFloatSlice(ratiosId)
+	if !reflect.DeepEqual(ratios, []float64{0.5, 1.5}) {
+		t.Errorf("expected: `%v`, got: `%v`", []float64{0.5, 1.5}, ratios)
+	}
+}
+
+func Test_GetBoolSlice_ReturnsCopy(t *testing.T) {
+	t.Setenv("FOO", "true,false")
+
+	var fooId gofig.Id
+
+	gf, err := gofig.Init([]gofig.InitOpt{
+		{Name: "FOO", Type: gofig.TypeBoolSlice, Required: true, IdPtr: &fooId},
+	})
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+
+	first, _ := gf.GetBoolSlice(fooId)
+	first[0] = false
+
+	second, _ := gf.GetBoolSlice(fooId)
+	if !second[0] {
+		t.Error("changing a slice returned by GetBoolSlice changed the value in Gofig")
+	}
+}
+
+func Test_GetInt_Err_When_IdIsForSlice(t *testing.T) {
+	t.Setenv("FOO", "1,2")
+
+	var fooId gofig.Id
+
+	gf, err := gofig.Init([]gofig.InitOpt{
+		{Name: "FOO", Type: gofig.TypeIntSlice, Required: true, IdPtr: &fooId},
+	})
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+
+	_, errActual := gf.GetInt(fooId)
+	errExpected := gofig.ErrInvalidId
+	if errActual != errExpected {
+		t.Error(ErrErrorsDoNotMatch(errExpected, errActual))
+	}
+}
+
+func Test_DocString_SliceDefaultJoinedWithSeparator(t *testing.T) {
+	expectedDocStr := "FOO\n\tDescription: Some hosts\n\tType: []string\n\tRequired: false\n\tDefault: a;b\\;c\n"
+
+	actualDocStr, err := gofig.DocString([]gofig.InitOpt{
+		{Name: "FOO", Description: "Some hosts", Type: gofig.TypeStringSlice, Default: []string{"a", "b;c"}, Separator: ";"},
+	})
+	if err != nil {
+		t.Error(ErrExpectedNoError(err))
+	}
+	if actualDocStr != expectedDocStr {
+		t.Errorf("expected: `%v`, got: `%v`", expectedDocStr, actualDocStr)
+	}
+}
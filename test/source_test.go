@@ -0,0 +1,101 @@
+package gofig
+
+import (
+	"testing"
+
+	"github.com/ippontech/gofig"
+)
+
+func Test_InitWithSources_FirstSourceWins(t *testing.T) {
+	t.Setenv("FOO", "from env")
+
+	var fooId gofig.Id
+
+	initOpt := goodStringInitOpt
+	initOpt.IdPtr = &fooId
+
+	gf, err := gofig.InitWithSources(
+		[]gofig.InitOpt{initOpt},
+		gofig.MapSource{"FOO": "from map"},
+		gofig.EnvSource{},
+	)
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+
+	foo, err := gf.GetString(fooId)
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+	if foo != "from map" {
+		t.Errorf("expected: `%v`, got: `%v`", "from map", foo)
+	}
+}
+
+func Test_InitWithSources_FallsThroughToLaterSource(t *testing.T) {
+	t.Setenv("FOO", "42")
+
+	var fooId gofig.Id
+
+	initOpt := goodIntInitOpt
+	initOpt.IdPtr = &fooId
+
+	gf, err := gofig.InitWithSources(
+		[]gofig.InitOpt{initOpt},
+		gofig.MapSource{"BAR": "1"},
+		gofig.EnvSource{},
+	)
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+
+	foo, err := gf.GetInt(fooId)
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+	if foo != 42 {
+		t.Errorf("expected: `%v`, got: `%v`", 42, foo)
+	}
+}
+
+func Test_InitWithSources_Err_When_RequiredNotInAnySource(t *testing.T) {
+	var fooId gofig.Id
+
+	initOpt := goodStringInitOpt
+	initOpt.IdPtr = &fooId
+
+	_, errActual := gofig.InitWithSources([]gofig.InitOpt{initOpt}, gofig.MapSource{})
+
+	errExpected := gofig.ErrRequiredConfigNotSet("FOO")
+	if errActual == nil || errActual.Error() != errExpected.Error() {
+		t.Error(ErrErrorsDoNotMatch(errExpected, errActual))
+	}
+}
+
+func Test_InitWithSources_Err_When_SourceValueCannotBeConvertedToInt(t *testing.T) {
+	var fooId gofig.Id
+
+	initOpt := goodIntInitOpt
+	initOpt.IdPtr = &fooId
+
+	_, errActual := gofig.InitWithSources([]gofig.InitOpt{initOpt}, gofig.MapSource{"FOO": "not an int"})
+
+	errExpected := gofig.ErrWrongTypeSetInSource(initOpt, "not an int", "map")
+	if errActual == nil || errActual.Error() != errExpected.Error() {
+		t.Error(ErrErrorsDoNotMatch(errExpected, errActual))
+	}
+}
+
+func Test_InitWithSources_Err_When_NoSourcesPassed(t *testing.T) {
+	var fooId gofig.Id
+
+	initOpt := goodStringInitOpt
+	initOpt.IdPtr = &fooId
+
+	_, errActual := gofig.InitWithSources([]gofig.InitOpt{initOpt})
+
+	errExpected := gofig.ErrNoSources
+	if errActual != errExpected {
+		t.Error(ErrErrorsDoNotMatch(errExpected, errActual))
+	}
+}
@@ -0,0 +1,91 @@
+package gofig
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ippontech/gofig"
+)
+
+func Test_Init_DurationAndTime(t *testing.T) {
+	t.Setenv("TIMEOUT", "1m30s")
+	t.Setenv("START", "2024-03-01T12:00:00Z")
+
+	var timeoutId gofig.Id
+	var startId gofig.Id
+
+	gf, err := gofig.Init([]gofig.InitOpt{
+		{Name: "TIMEOUT", Type: gofig.TypeDuration, Required: true, IdPtr: &timeoutId},
+		{Name: "START", Type: gofig.TypeTime, Required: true, IdPtr: &startId},
+	})
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+
+	timeout, err := gf.GetDuration(timeoutId)
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+	if timeout != 90*time.Second {
+		t.Errorf("expected: `%v`, got: `%v`", 90*time.Second, timeout)
+	}
+
+	start, err := gf.GetTime(startId)
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+	expectedStart := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	if !start.Equal(expectedStart) {
+		t.Errorf("expected: `%v`, got: `%v`", expectedStart, start)
+	}
+}
+
+func Test_Init_Err_When_EnvVarCannotBeConvertedToDuration(t *testing.T) {
+	t.Setenv("TIMEOUT", "90")
+
+	var timeoutId gofig.Id
+
+	initOpt := gofig.InitOpt{Name: "TIMEOUT", Type: gofig.TypeDuration, Required: true, IdPtr: &timeoutId}
+
+	_, errActual := gofig.Init([]gofig.InitOpt{initOpt})
+
+	errExpected := gofig.ErrWrongTypeSetInEnvironment(initOpt, "90")
+	if errActual == nil || errActual.Error() != errExpected.Error() {
+		t.Error(ErrErrorsDoNotMatch(errExpected, errActual))
+	}
+}
+
+func Test_Init_Err_When_DurationDefaultTypeIncorrect(t *testing.T) {
+	var timeoutId gofig.Id
+
+	badInitOpt := gofig.InitOpt{
+		Name:     "TIMEOUT",
+		Type:     gofig.TypeDuration,
+		Required: false,
+		Default:  30, // an int, not a time.Duration
+		IdPtr:    &timeoutId,
+	}
+
+	_, errActual := gofig.Init([]gofig.InitOpt{badInitOpt})
+
+	errExpected := gofig.ErrDefaultValueIsWrongTypeWhenNotRequired(badInitOpt)
+	if errActual == nil || errActual.Error() != errExpected.Error() {
+		t.Error(ErrErrorsDoNotMatch(errExpected, errActual))
+	}
+}
+
+func Test_DocString_DurationAndTimeDefaults(t *testing.T) {
+	expectedDocStr := "TIMEOUT\n\tDescription: \n\tType: duration\n\tRequired: false\n\tDefault: 1m30s\n" +
+		"START\n\tDescription: \n\tType: time\n\tRequired: false\n\tDefault: 2024-03-01T12:00:00Z\n"
+
+	actualDocStr, err := gofig.DocString([]gofig.InitOpt{
+		{Name: "TIMEOUT", Type: gofig.TypeDuration, Default: 90 * time.Second},
+		{Name: "START", Type: gofig.TypeTime, Default: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)},
+	})
+	if err != nil {
+		t.Error(ErrExpectedNoError(err))
+	}
+	if actualDocStr != expectedDocStr {
+		t.Errorf("expected: `%v`, got: `%v`", expectedDocStr, actualDocStr)
+	}
+}
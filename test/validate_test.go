@@ -0,0 +1,156 @@
+package gofig
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ippontech/gofig"
+)
+
+func Test_Init_ErrNil_When_ValidatorsPass(t *testing.T) {
+	t.Setenv("DATABASE_ENGINE", "postgres")
+	t.Setenv("DATABASE_PORT", "5432")
+	t.Setenv("ADMIN_EMAIL", "admin@example.com")
+	t.Setenv("ALLOWED_HOSTS", "localhost,db.example.com")
+
+	var engineId gofig.Id
+	var portId gofig.Id
+	var emailId gofig.Id
+	var hostsId gofig.Id
+
+	_, err := gofig.Init([]gofig.InitOpt{
+		{Name: "DATABASE_ENGINE", Type: gofig.TypeString, Required: true, IdPtr: &engineId, Validators: []gofig.Validator{gofig.OneOf("postgres", "mysql", "sqlite")}},
+		{Name: "DATABASE_PORT", Type: gofig.TypeInt, Required: true, IdPtr: &portId, Validators: []gofig.Validator{gofig.Port(), gofig.Min(1024)}},
+		{Name: "ADMIN_EMAIL", Type: gofig.TypeString, Required: true, IdPtr: &emailId, Validators: []gofig.Validator{gofig.Email(), gofig.MaxLen(64)}},
+		{Name: "ALLOWED_HOSTS", Type: gofig.TypeStringSlice, Required: true, IdPtr: &hostsId, Validators: []gofig.Validator{gofig.MinLen(1), gofig.Hostname()}},
+	})
+	if err != nil {
+		t.Error(ErrExpectedNoError(err))
+	}
+}
+
+func Test_Init_Err_When_OneOfFails(t *testing.T) {
+	t.Setenv("DATABASE_ENGINE", "oracle")
+
+	var engineId gofig.Id
+
+	oneOf := gofig.OneOf("postgres", "mysql", "sqlite")
+	initOpt := gofig.InitOpt{Name: "DATABASE_ENGINE", Type: gofig.TypeString, Required: true, IdPtr: &engineId, Validators: []gofig.Validator{oneOf}}
+
+	_, errActual := gofig.Init([]gofig.InitOpt{initOpt})
+
+	errExpected := gofig.ErrValidationFailed(initOpt, "oneOf(postgres, mysql, sqlite)", errors.New("`oracle` is not one of the allowed values"))
+	if errActual == nil || errActual.Error() != errExpected.Error() {
+		t.Error(ErrErrorsDoNotMatch(errExpected, errActual))
+	}
+}
+
+func Test_Init_Err_When_OneOfValueIsWrongType(t *testing.T) {
+	tests := []struct {
+		t       gofig.GfType
+		allowed any
+	}{
+		{gofig.TypeFloat, 1},
+		{gofig.TypeDuration, 5},
+		{gofig.TypeIntSlice, "1"},
+		{gofig.TypeStringMap, 1},
+	}
+
+	for _, test := range tests {
+		var id gofig.Id
+		oneOf := gofig.OneOf(test.allowed)
+		initOpt := gofig.InitOpt{Name: "FOO", Type: test.t, Required: true, IdPtr: &id, Validators: []gofig.Validator{oneOf}}
+
+		_, errActual := gofig.InitWithSources([]gofig.InitOpt{initOpt}, gofig.MapSource{})
+
+		errExpected := gofig.ErrAllowedValueWrongType(initOpt, oneOf.Rule(), test.allowed)
+		if errActual == nil || errActual.Error() != errExpected.Error() {
+			t.Error(ErrErrorsDoNotMatch(errExpected, errActual))
+		}
+	}
+}
+
+func Test_Init_OneOfMatchesFloatsAndDurations(t *testing.T) {
+	var ratioId, timeoutId gofig.Id
+
+	_, err := gofig.InitWithSources([]gofig.InitOpt{
+		{Name: "RATIO", Type: gofig.TypeFloat, Required: true, IdPtr: &ratioId, Validators: []gofig.Validator{gofig.OneOf(1.0, 2.0)}},
+		{Name: "TIMEOUT", Type: gofig.TypeDuration, Required: true, IdPtr: &timeoutId, Validators: []gofig.Validator{gofig.OneOf(time.Second, time.Minute)}},
+	}, gofig.MapSource{"RATIO": "1", "TIMEOUT": "1m"})
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+}
+
+func Test_Init_OneOfMatchesSameInstantInOtherLocation(t *testing.T) {
+	var startId gofig.Id
+
+	_, err := gofig.InitWithSources([]gofig.InitOpt{
+		{Name: "START", Type: gofig.TypeTime, Required: true, IdPtr: &startId, Validators: []gofig.Validator{gofig.OneOf(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))}},
+	}, gofig.MapSource{"START": "2024-01-01T13:00:00+01:00"})
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+}
+
+func Test_Init_Err_When_MaxFailsOnSliceElement(t *testing.T) {
+	t.Setenv("LIMITS", "1.5,2.5,10")
+
+	var limitsId gofig.Id
+
+	initOpt := gofig.InitOpt{Name: "LIMITS", Type: gofig.TypeFloatSlice, Required: true, IdPtr: &limitsId, Validators: []gofig.Validator{gofig.Max(5)}}
+
+	_, errActual := gofig.Init([]gofig.InitOpt{initOpt})
+
+	errExpected := gofig.ErrValidationFailed(initOpt, "max(5)", errors.New("`10` is greater than 5"))
+	if errActual == nil || errActual.Error() != errExpected.Error() {
+		t.Error(ErrErrorsDoNotMatch(errExpected, errActual))
+	}
+}
+
+func Test_Init_Err_When_MatchesFails(t *testing.T) {
+	t.Setenv("REGION", "EU-WEST")
+
+	var regionId gofig.Id
+
+	initOpt := gofig.InitOpt{Name: "REGION", Type: gofig.TypeString, Required: true, IdPtr: &regionId, Validators: []gofig.Validator{gofig.Matches(`^[a-z]+-[a-z]+$`)}}
+
+	_, errActual := gofig.Init([]gofig.InitOpt{initOpt})
+
+	errExpected := gofig.ErrValidationFailed(initOpt, "matches(^[a-z]+-[a-z]+$)", errors.New("`EU-WEST` does not match"))
+	if errActual == nil || errActual.Error() != errExpected.Error() {
+		t.Error(ErrErrorsDoNotMatch(errExpected, errActual))
+	}
+}
+
+func Test_Init_Err_When_ValidatorFuncFails(t *testing.T) {
+	t.Setenv("WORKERS", "3")
+
+	var workersId gofig.Id
+
+	even := gofig.ValidatorFunc("even", func(val any) error {
+		if val.(int)%2 != 0 {
+			return errors.New("must be even")
+		}
+		return nil
+	})
+	initOpt := gofig.InitOpt{Name: "WORKERS", Type: gofig.TypeInt, Required: true, IdPtr: &workersId, Validators: []gofig.Validator{even}}
+
+	_, errActual := gofig.Init([]gofig.InitOpt{initOpt})
+
+	errExpected := gofig.ErrValidationFailed(initOpt, "even", errors.New("must be even"))
+	if errActual == nil || errActual.Error() != errExpected.Error() {
+		t.Error(ErrErrorsDoNotMatch(errExpected, errActual))
+	}
+}
+
+func Test_URLValidator(t *testing.T) {
+	v := gofig.URL()
+	if err := v.Validate("https://example.com/path"); err != nil {
+		t.Error(ErrExpectedNoError(err))
+	}
+	if err := v.Validate("example.com/path"); err == nil {
+		t.Error(ErrExpectedError)
+	}
+}
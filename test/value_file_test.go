@@ -0,0 +1,169 @@
+package gofig
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/ippontech/gofig"
+)
+
+func Test_FromFile_ReadsFileWhenUnset(t *testing.T) {
+	var passwordId gofig.Id
+	path := writeTempFile(t, "db_pw", "hunter2\n")
+
+	gf, err := gofig.InitWithSources([]gofig.InitOpt{
+		{Name: "DATABASE_PASSWORD", Type: gofig.TypeString, Required: true, FromFile: true, IdPtr: &passwordId},
+	}, gofig.MapSource{"DATABASE_PASSWORD_FILE": path})
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+
+	password, _ := gf.GetString(passwordId)
+	if password != "hunter2" {
+		t.Errorf("expected: `%v`, got: `%v`", "hunter2", password)
+	}
+	origin, _ := gf.Origin(passwordId)
+	if origin.Kind != gofig.SourceFile || origin.Label != path {
+		t.Errorf("expected origin: `file %s`, got: `%v`", path, origin)
+	}
+}
+
+func Test_FromFile_NameWinsOverFile(t *testing.T) {
+	var passwordId gofig.Id
+	path := writeTempFile(t, "db_pw", "from-file")
+
+	gf, err := gofig.InitWithSources([]gofig.InitOpt{
+		{Name: "DATABASE_PASSWORD", Type: gofig.TypeString, Required: true, FromFile: true, IdPtr: &passwordId},
+	}, gofig.MapSource{"DATABASE_PASSWORD": "from-source", "DATABASE_PASSWORD_FILE": path})
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+
+	if password, _ := gf.GetString(passwordId); password != "from-source" {
+		t.Errorf("expected: `%v`, got: `%v`", "from-source", password)
+	}
+}
+
+func Test_FromFile_OnlyTrimsOneTrailingNewline(t *testing.T) {
+	tests := map[string]string{
+		"a\n":     "a",
+		"a\r\n":   "a",
+		"a\n\n":   "a\n",
+		" a b \n": " a b ",
+		"a":       "a",
+	}
+
+	for contents, expected := range tests {
+		var valId gofig.Id
+		path := writeTempFile(t, "val", contents)
+
+		gf, err := gofig.InitWithSources([]gofig.InitOpt{
+			{Name: "VAL", Type: gofig.TypeString, Required: true, FromFile: true, IdPtr: &valId},
+		}, gofig.MapSource{"VAL_FILE": path})
+		if err != nil {
+			t.Fatal(ErrExpectedNoError(err))
+		}
+		if val, _ := gf.GetString(valId); val != expected {
+			t.Errorf("contents: %q. expected: %q, got: %q", contents, expected, val)
+		}
+	}
+}
+
+func Test_FromFile_Settings(t *testing.T) {
+	var portId, hostId gofig.Id
+	path := writeTempFile(t, "port", "5432\n")
+	initOpts := []gofig.InitOpt{
+		{Name: "PORT", Type: gofig.TypeInt, Required: true, IdPtr: &portId},
+		{Name: "HOST", Type: gofig.TypeString, Default: "localhost", IdPtr: &hostId},
+	}
+	sources := []gofig.Source{gofig.MapSource{"PORT_FILE": path}}
+
+	_, errActual := gofig.InitWithSettings(initOpts, gofig.Settings{Sources: sources})
+	if !errors.Is(errActual, gofig.ErrRequiredNotSet) {
+		t.Errorf("expected `%v` without FromFile, got: `%v`", gofig.ErrRequiredNotSet, errActual)
+	}
+
+	gf, err := gofig.InitWithSettings(initOpts, gofig.Settings{Sources: sources, FromFile: true})
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+	if port, _ := gf.GetInt(portId); port != 5432 {
+		t.Errorf("expected: `%v`, got: `%v`", 5432, port)
+	}
+	if host, _ := gf.GetString(hostId); host != "localhost" {
+		t.Errorf("expected: `%v`, got: `%v`", "localhost", host)
+	}
+}
+
+func Test_FromFile_Err_When_FileCantBeRead(t *testing.T) {
+	dir := t.TempDir()
+	worldWritable := writeTempFile(t, "world", "a")
+	if err := os.Chmod(worldWritable, 0o666); err != nil {
+		t.Fatal(err)
+	}
+	tooLarge := writeTempFile(t, "large", strings.Repeat("a", 11))
+
+	tests := []struct {
+		path   string
+		reason error
+	}{
+		{dir + "/missing", fs.ErrNotExist},
+		{dir, gofig.ErrFileIsDir},
+		{worldWritable, gofig.ErrFileWorldWritable},
+		{tooLarge, nil},
+	}
+
+	for _, test := range tests {
+		var valId gofig.Id
+		_, errActual := gofig.InitWithSettings([]gofig.InitOpt{
+			{Name: "VAL", Type: gofig.TypeString, Required: true, FromFile: true, IdPtr: &valId},
+		}, gofig.Settings{Sources: []gofig.Source{gofig.MapSource{"VAL_FILE": test.path}}, MaxFileSize: 10})
+
+		var fileErr *gofig.ValueFileError
+		if !errors.As(errActual, &fileErr) || !errors.Is(errActual, gofig.ErrValueFile) {
+			t.Errorf("path: `%s`. expected a *ValueFileError, got: `%v`", test.path, errActual)
+			continue
+		}
+		if fileErr.Path != test.path || !strings.Contains(errActual.Error(), test.path) {
+			t.Errorf("expected the error to name `%s`, got: `%v`", test.path, errActual)
+		}
+		if test.reason != nil && !errors.Is(errActual, test.reason) {
+			t.Errorf("path: `%s`. expected: `%v`, got: `%v`", test.path, test.reason, errActual)
+		}
+	}
+}
+
+func Test_FromFile_Err_When_WrongTypeNamesFile(t *testing.T) {
+	var portId gofig.Id
+	path := writeTempFile(t, "port", "not-a-port\n")
+
+	_, errActual := gofig.InitWithSources([]gofig.InitOpt{
+		{Name: "PORT", Type: gofig.TypeInt, Required: true, FromFile: true, IdPtr: &portId},
+	}, gofig.MapSource{"PORT_FILE": path})
+
+	var convErr *gofig.ConversionError
+	if !errors.As(errActual, &convErr) {
+		t.Fatal(ErrErrorsDoNotMatch(&gofig.ConversionError{}, errActual))
+	}
+	if convErr.Source != path {
+		t.Errorf("expected source: `%v`, got: `%v`", path, convErr.Source)
+	}
+}
+
+func Test_Bind_FromFileTag(t *testing.T) {
+	var cfg struct {
+		Password string `gofig:"DATABASE_PASSWORD" required:"true" secret:"true" fromfile:"true"`
+	}
+	path := writeTempFile(t, "db_pw", "hunter2\n")
+
+	err := gofig.BindWithSettings(&cfg, gofig.Settings{Sources: []gofig.Source{gofig.MapSource{"DATABASE_PASSWORD_FILE": path}}})
+	if err != nil {
+		t.Fatal(ErrExpectedNoError(err))
+	}
+	if cfg.Password != "hunter2" {
+		t.Errorf("expected: `%v`, got: `%v`", "hunter2", cfg.Password)
+	}
+}
@@ -0,0 +1,143 @@
+package gofig
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+/*
+NewTomlSource reads and parses the TOML config file at the path passed in.
+Strings, numbers and booleans are used as is, and dates are formatted as RFC3339.
+Arrays and tables are turned into inline TOML (e.g. `["a", "b"]`).
+TOML doesn't give us line numbers, so the label of a value is just the path of the file.
+*/
+func NewTomlSource(path string) (*FileSource, error) {
+	var doc map[string]any
+	md, err := toml.DecodeFile(path, &doc)
+	if err != nil {
+		if _, isParseErr := err.(toml.ParseError); isParseErr {
+			return nil, ErrParsingConfigFile(path, err)
+		}
+		return nil, ErrReadingConfigFile(path, err)
+	}
+
+	fs := newFileSource(path)
+
+	// md.Keys() is in the order of the document, so duplicate key errors are reported consistently
+	for _, key := range md.Keys() {
+		val, ok := tomlValueAt(doc, key)
+		if !ok {
+			// array of tables members, which are part of the array's value
+			continue
+		}
+		entry := fileEntry{raw: tomlString(val)}
+		entry.elems, entry.isList = tomlScalars(val)
+		entry.pairs, entry.isMap = tomlScalarPairs(val)
+		if err := fs.add(key, entry); err != nil {
+			return nil, err
+		}
+	}
+	return fs, nil
+}
+
+// tomlValueAt returns the value at key in the decoded document
+func tomlValueAt(doc map[string]any, key toml.Key) (any, bool) {
+	var cur any = doc
+	for _, part := range key {
+		table, ok := cur.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if cur, ok = table[part]; !ok {
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+// tomlScalars returns the elements of an array if they are all scalars
+func tomlScalars(val any) ([]string, bool) {
+	arr, ok := val.([]any)
+	if !ok {
+		return nil, false
+	}
+	elems := make([]string, len(arr))
+	for i, elem := range arr {
+		switch elem.(type) {
+		case []any, []map[string]any, map[string]any:
+			return nil, false
+		}
+		elems[i] = tomlString(elem)
+	}
+	return elems, true
+}
+
+// tomlScalarPairs returns the members of a table if their values are all scalars
+func tomlScalarPairs(val any) (map[string]string, bool) {
+	table, ok := val.(map[string]any)
+	if !ok {
+		return nil, false
+	}
+	pairs := make(map[string]string, len(table))
+	for k, member := range table {
+		switch member.(type) {
+		case []any, []map[string]any, map[string]any:
+			return nil, false
+		}
+		pairs[k] = tomlString(member)
+	}
+	return pairs, true
+}
+
+// tomlString turns a decoded TOML value into the raw string Init converts
+func tomlString(val any) string {
+	switch v := val.(type) {
+	case string:
+		return v
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case []any:
+		elems := make([]string, len(v))
+		for i, elem := range v {
+			elems[i] = tomlInlineString(elem)
+		}
+		return "[" + strings.Join(elems, ", ") + "]"
+	case []map[string]any:
+		elems := make([]string, len(v))
+		for i, elem := range v {
+			elems[i] = tomlInlineString(elem)
+		}
+		return "[" + strings.Join(elems, ", ") + "]"
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		members := make([]string, len(keys))
+		for i, k := range keys {
+			members[i] = fmt.Sprintf("%s = %s", k, tomlInlineString(v[k]))
+		}
+		return "{" + strings.Join(members, ", ") + "}"
+	}
+	return fmt.Sprint(val)
+}
+
+// tomlInlineString is tomlString with strings quoted, for values nested in arrays and tables
+func tomlInlineString(val any) string {
+	if s, ok := val.(string); ok {
+		return strconv.Quote(s)
+	}
+	return tomlString(val)
+}
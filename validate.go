@@ -0,0 +1,353 @@
+package gofig
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+var ErrValidationFailed = func(initOpt InitOpt, rule string, reason error) error {
+	return &ValidationError{Name: initOpt.Name, Rule: rule, Err: reason, Secret: initOpt.Secret}
+}
+var ErrNilValidator = func(initOpt InitOpt) error {
+	return fmt.Errorf("config `%s` has a nil validator", initOpt.Name)
+}
+var ErrAllowedValueWrongType = func(initOpt InitOpt, rule string, allowed any) error {
+	return fmt.Errorf("config `%s` has validator `%s` with allowed value `%v` of type `%T`. allowed values must be of type `%s`",
+		initOpt.Name, rule, allowed, allowed, elemGoType(initOpt.Type))
+}
+
+/*
+Validator is a rule the value of a config option must follow.
+Init runs the Validators of each config option after converting its value, in order, and fails on the first one that doesn't pass.
+*/
+type Validator interface {
+	Rule() string           // A short description of the rule (e.g. "min(1)"). Used in errors and docs.
+	Validate(val any) error // Returns why the value doesn't follow the rule, or nil if it does.
+}
+
+type validator struct {
+	rule    string
+	check   func(val any) error
+	allowed []any // the allowed values of OneOf, for docs
+}
+
+func (v validator) Rule() string {
+	return v.rule
+}
+
+func (v validator) Validate(val any) error {
+	return v.check(val)
+}
+
+/*
+ValidatorFunc makes a Validator out of a function. rule describes the rule in errors and docs.
+The function is given the whole value of the config option (e.g. the whole slice for slice types).
+*/
+func ValidatorFunc(rule string, fn func(val any) error) Validator {
+	return validator{rule: rule, check: fn}
+}
+
+/*
+Min checks that an int or float is at least min.
+Like all the validators below, except the length ones, it checks each element of slice types and each value of TypeStringMap.
+*/
+func Min(min float64) Validator {
+	return validator{
+		rule: fmt.Sprintf("min(%v)", min),
+		check: eachElem(func(val any) error {
+			num, err := toFloat(val)
+			if err != nil {
+				return err
+			}
+			if num < min {
+				return fmt.Errorf("`%v` is less than %v", val, min)
+			}
+			return nil
+		}),
+	}
+}
+
+// Max checks that an int or float is at most max.
+func Max(max float64) Validator {
+	return validator{
+		rule: fmt.Sprintf("max(%v)", max),
+		check: eachElem(func(val any) error {
+			num, err := toFloat(val)
+			if err != nil {
+				return err
+			}
+			if num > max {
+				return fmt.Errorf("`%v` is greater than %v", val, max)
+			}
+			return nil
+		}),
+	}
+}
+
+// MinLen checks that a string has at least min characters, or that a slice or map has at least min elements.
+func MinLen(min int) Validator {
+	return validator{
+		rule: fmt.Sprintf("minLen(%d)", min),
+		check: func(val any) error {
+			n, err := length(val)
+			if err != nil {
+				return err
+			}
+			if n < min {
+				return fmt.Errorf("length %d is less than %d", n, min)
+			}
+			return nil
+		},
+	}
+}
+
+// MaxLen checks that a string has at most max characters, or that a slice or map has at most max elements.
+func MaxLen(max int) Validator {
+	return validator{
+		rule: fmt.Sprintf("maxLen(%d)", max),
+		check: func(val any) error {
+			n, err := length(val)
+			if err != nil {
+				return err
+			}
+			if n > max {
+				return fmt.Errorf("length %d is greater than %d", n, max)
+			}
+			return nil
+		},
+	}
+}
+
+/*
+Matches checks that a string matches the regular expression passed in.
+Like regexp.MustCompile, it panics if the regular expression is invalid.
+*/
+func Matches(pattern string) Validator {
+	re := regexp.MustCompile(pattern)
+	return validator{
+		rule: fmt.Sprintf("matches(%s)", pattern),
+		check: eachElem(func(val any) error {
+			str, ok := val.(string)
+			if !ok {
+				return errNotApplicable(val)
+			}
+			if !re.MatchString(str) {
+				return fmt.Errorf("`%s` does not match", str)
+			}
+			return nil
+		}),
+	}
+}
+
+/*
+OneOf checks that the value is one of the allowed values (e.g. OneOf("postgres", "mysql", "sqlite")).
+The allowed values must be of the Go type of the config option, or of its elements for slice types and TypeStringMap
+(e.g. OneOf(1.0, 2.0) for TypeFloat, OneOf(time.Second, time.Minute) for TypeDuration). Init fails otherwise.
+*/
+func OneOf(allowed ...any) Validator {
+	strs := make([]string, len(allowed))
+	for i, a := range allowed {
+		strs[i] = fmt.Sprintf("%v", a)
+	}
+	return validator{
+		rule:    fmt.Sprintf("oneOf(%s)", strings.Join(strs, ", ")),
+		allowed: allowed,
+		check: eachElem(func(val any) error {
+			for _, a := range allowed {
+				if isSameValue(val, a) {
+					return nil
+				}
+			}
+			return fmt.Errorf("`%v` is not one of the allowed values", val)
+		}),
+	}
+}
+
+// isSameValue compares times with Equal, so the same instant in another location matches, and other values with ==
+func isSameValue(val any, allowed any) bool {
+	if t, ok := val.(time.Time); ok {
+		a, ok := allowed.(time.Time)
+		return ok && t.Equal(a)
+	}
+	return val == allowed
+}
+
+// Email checks that a string is a bare email address (e.g. "gofig@example.com").
+func Email() Validator {
+	return validator{
+		rule: "email",
+		check: eachElem(func(val any) error {
+			str, ok := val.(string)
+			if !ok {
+				return errNotApplicable(val)
+			}
+			addr, err := mail.ParseAddress(str)
+			if err != nil || addr.Address != str {
+				return fmt.Errorf("`%s` is not an email address", str)
+			}
+			return nil
+		}),
+	}
+}
+
+// URL checks that a string is an absolute URL with a scheme and a host (e.g. "https://example.com/path").
+func URL() Validator {
+	return validator{
+		rule: "url",
+		check: eachElem(func(val any) error {
+			str, ok := val.(string)
+			if !ok {
+				return errNotApplicable(val)
+			}
+			u, err := url.Parse(str)
+			if err != nil || u.Scheme == "" || u.Host == "" {
+				return fmt.Errorf("`%s` is not an absolute URL", str)
+			}
+			return nil
+		}),
+	}
+}
+
+var hostnameLabel = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?$`)
+
+// Hostname checks that a string is a valid hostname (RFC 1123), such as "db.example.com" or "localhost".
+func Hostname() Validator {
+	return validator{
+		rule: "hostname",
+		check: eachElem(func(val any) error {
+			str, ok := val.(string)
+			if !ok {
+				return errNotApplicable(val)
+			}
+			errNotHostname := fmt.Errorf("`%s` is not a hostname", str)
+			if str == "" || len(str) > 253 {
+				return errNotHostname
+			}
+			for _, label := range strings.Split(strings.TrimSuffix(str, "."), ".") {
+				if !hostnameLabel.MatchString(label) {
+					return errNotHostname
+				}
+			}
+			return nil
+		}),
+	}
+}
+
+// Port checks that an int, or a string holding an int, is a port number between 1 and 65535.
+func Port() Validator {
+	return validator{
+		rule: "port",
+		check: eachElem(func(val any) error {
+			port, ok := val.(int)
+			if str, isStr := val.(string); isStr {
+				var err error
+				port, err = strconv.Atoi(str)
+				ok = err == nil
+			}
+			if !ok || port < 1 || port > 65535 {
+				return fmt.Errorf("`%v` is not a port number between 1 and 65535", val)
+			}
+			return nil
+		}),
+	}
+}
+
+// validate runs the validators of the config option on its value
+func validate(initOpt InitOpt, val any) error {
+	for _, v := range initOpt.Validators {
+		if v == nil {
+			return ErrNilValidator(initOpt)
+		}
+		if err := v.Validate(val); err != nil {
+			return ErrValidationFailed(initOpt, v.Rule(), err)
+		}
+	}
+	return nil
+}
+
+/*
+checkValidators checks that the allowed values of the OneOf validators of the config option are of its Go type,
+since they are compared with == and would never match otherwise.
+*/
+func checkValidators(initOpt InitOpt) error {
+	goType := elemGoType(initOpt.Type)
+	for _, v := range initOpt.Validators {
+		v, ok := v.(validator)
+		if !ok {
+			continue
+		}
+		for _, a := range v.allowed {
+			if reflect.TypeOf(a) != goType {
+				return ErrAllowedValueWrongType(initOpt, v.rule, a)
+			}
+		}
+	}
+	return nil
+}
+
+// elemGoType returns the Go type of the values of a GfType, or of their elements for slice and map types
+func elemGoType(t GfType) reflect.Type {
+	goType := goTypes[t]
+	if goType.Kind() == reflect.Slice || goType.Kind() == reflect.Map {
+		return goType.Elem()
+	}
+	return goType
+}
+
+// eachElem applies check to each element of slices and each value of maps, and to other values directly
+func eachElem(check func(val any) error) func(val any) error {
+	return func(val any) error {
+		rv := reflect.ValueOf(val)
+		switch rv.Kind() {
+		case reflect.Slice:
+			for i := 0; i < rv.Len(); i++ {
+				if err := check(rv.Index(i).Interface()); err != nil {
+					return err
+				}
+			}
+			return nil
+		case reflect.Map:
+			iter := rv.MapRange()
+			for iter.Next() {
+				if err := check(iter.Value().Interface()); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		return check(val)
+	}
+}
+
+func toFloat(val any) (float64, error) {
+	switch v := val.(type) {
+	case int:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	}
+	return 0, errNotApplicable(val)
+}
+
+func length(val any) (int, error) {
+	if str, ok := val.(string); ok {
+		return utf8.RuneCountInString(str), nil
+	}
+	rv := reflect.ValueOf(val)
+	if rv.Kind() == reflect.Slice || rv.Kind() == reflect.Map {
+		return rv.Len(), nil
+	}
+	return 0, errNotApplicable(val)
+}
+
+func errNotApplicable(val any) error {
+	return errors.New("rule does not apply to values of type " + reflect.TypeOf(val).String())
+}
@@ -0,0 +1,107 @@
+package gofig
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+/*
+FileSuffix is added to the name of a config option to get the name that can point to a file holding its value
+(e.g. DATABASE_PASSWORD_FILE=/run/secrets/db_pw for DATABASE_PASSWORD), the way Docker and Kubernetes mount secrets.
+See InitOpt.FromFile.
+*/
+const FileSuffix = "_FILE"
+
+/*
+DefaultMaxFileSize is the largest file, in bytes, read for a config option when Settings.MaxFileSize isn't set.
+*/
+const DefaultMaxFileSize int64 = 64 * 1024
+
+var ErrFileWorldWritable = errors.New("file is writable by anyone. make it writable only by its owner or group")
+var ErrFileIsDir = errors.New("file is a directory")
+var ErrFileTooLarge = func(maxSize int64) error {
+	return fmt.Errorf("file is larger than %d bytes", maxSize)
+}
+var ErrValueFileRead = func(initOpt InitOpt, path string, reason error) error {
+	return &ValueFileError{Name: initOpt.Name, Path: path, Err: reason}
+}
+
+// valueFiles is how InitWithSettings reads config options from files
+type valueFiles struct {
+	all     bool  // whether every config option can be read from a file, not only the ones with FromFile
+	maxSize int64 // the largest file read
+}
+
+func newValueFiles(settings Settings) valueFiles {
+	files := valueFiles{all: settings.FromFile, maxSize: settings.MaxFileSize}
+	if files.maxSize <= 0 {
+		files.maxSize = DefaultMaxFileSize
+	}
+	return files
+}
+
+func (files valueFiles) enabled(initOpt InitOpt) bool {
+	return files.all || initOpt.FromFile
+}
+
+/*
+lookupValueFile looks up NAME_FILE in the sources and reads the value of the config option from the file it names.
+An empty path is the same as NAME_FILE not being set. label is the path of the file.
+*/
+func lookupValueFile(initOpt InitOpt, sources []Source, files valueFiles) (raw string, found bool, label string, err error) {
+	path, found, _, _ := lookupSources(sources, initOpt.Name+FileSuffix)
+	if !found || path == "" {
+		return "", false, "", nil
+	}
+
+	raw, err = readValueFile(path, files.maxSize)
+	if err != nil {
+		return "", true, path, ErrValueFileRead(initOpt, path, err)
+	}
+	return raw, true, path, nil
+}
+
+/*
+readValueFile reads a file holding the value of a config option.
+Files writable by anyone and files larger than maxSize are rejected.
+One trailing newline ("\n" or "\r\n") is trimmed, since editors and `echo` add one.
+*/
+func readValueFile(path string, maxSize int64) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return "", err
+	}
+	if info.IsDir() {
+		return "", ErrFileIsDir
+	}
+	if info.Mode().Perm()&0o002 != 0 {
+		return "", ErrFileWorldWritable
+	}
+	if info.Size() > maxSize {
+		return "", ErrFileTooLarge(maxSize)
+	}
+
+	// the size from Stat isn't reliable for every file (e.g. in /proc), so the read is limited as well
+	data, err := io.ReadAll(io.LimitReader(f, maxSize+1))
+	if err != nil {
+		return "", err
+	}
+	if int64(len(data)) > maxSize {
+		return "", ErrFileTooLarge(maxSize)
+	}
+
+	raw := string(data)
+	if strings.HasSuffix(raw, "\r\n") {
+		return strings.TrimSuffix(raw, "\r\n"), nil
+	}
+	return strings.TrimSuffix(raw, "\n"), nil
+}
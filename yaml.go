@@ -0,0 +1,192 @@
+package gofig
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var ErrYamlMergeNotMapping = func(line int) error {
+	return fmt.Errorf("merge key on line %d must be a mapping or a sequence of mappings", line)
+}
+
+/*
+NewYamlSource reads and parses the YAML config file at the path passed in.
+Scalar values are used as is. Sequences and mappings are turned into flow style YAML (e.g. "[a, b]").
+Null values are treated as not set. A file that is empty, only whitespace or only comments has no values.
+*/
+func NewYamlSource(path string) (*FileSource, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, ErrReadingConfigFile(path, err)
+	}
+
+	fs := newFileSource(path)
+	if len(bytes.TrimSpace(data)) == 0 {
+		return fs, nil
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, ErrParsingConfigFile(path, err)
+	}
+	if len(doc.Content) == 0 {
+		// only comments
+		return fs, nil
+	}
+	root := resolveYamlAlias(doc.Content[0])
+	if root.Kind != yaml.MappingNode {
+		return nil, ErrConfigFileNotMapping(path)
+	}
+	if err := fs.addYamlMapping(nil, root); err != nil {
+		return nil, err
+	}
+	return fs, nil
+}
+
+func resolveYamlAlias(node *yaml.Node) *yaml.Node {
+	for node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	return node
+}
+
+func (fs *FileSource) addYamlMapping(keyPath []string, mapping *yaml.Node) error {
+	pairs, err := yamlMergedPairs(mapping)
+	if err != nil {
+		return ErrParsingConfigFile(fs.path, err)
+	}
+
+	for _, pair := range pairs {
+		keyNode, valNode := pair.key, pair.val
+		childPath := append(append([]string{}, keyPath...), keyNode.Value)
+
+		switch valNode.Kind {
+		case yaml.ScalarNode:
+			if valNode.Tag == "!!null" {
+				continue
+			}
+			if err := fs.add(childPath, fileEntry{raw: valNode.Value, line: valNode.Line}); err != nil {
+				return err
+			}
+		case yaml.MappingNode:
+			pairs, isMap := yamlScalarPairs(valNode)
+			entry := fileEntry{raw: yamlFlowString(valNode), line: keyNode.Line, isMap: isMap, pairs: pairs}
+			if err := fs.add(childPath, entry); err != nil {
+				return err
+			}
+			if err := fs.addYamlMapping(childPath, valNode); err != nil {
+				return err
+			}
+		case yaml.SequenceNode:
+			elems, isList := yamlScalars(valNode)
+			entry := fileEntry{raw: yamlFlowString(valNode), line: keyNode.Line, isList: isList, elems: elems}
+			if err := fs.add(childPath, entry); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// yamlPair is a key of a mapping and its value, with aliases resolved
+type yamlPair struct {
+	key *yaml.Node
+	val *yaml.Node
+}
+
+/*
+yamlMergedPairs returns the pairs of a mapping with its merge keys (`<<: *a` or `<<: [*a, *b]`) applied.
+Like YAML merges, keys set explicitly win over merged ones, and earlier merged mappings win over later ones.
+The merge is shallow: a key set explicitly replaces the whole value of the merged key.
+Keys set explicitly more than once are all returned, so they are still reported as duplicates.
+*/
+func yamlMergedPairs(mapping *yaml.Node) ([]yamlPair, error) {
+	var pairs []yamlPair
+	var merged []*yaml.Node
+	set := map[string]bool{}
+
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		keyNode := mapping.Content[i]
+		valNode := resolveYamlAlias(mapping.Content[i+1])
+		if keyNode.Tag != "!!merge" {
+			pairs = append(pairs, yamlPair{key: keyNode, val: valNode})
+			set[normalizeKey(keyNode.Value)] = true
+			continue
+		}
+
+		switch valNode.Kind {
+		case yaml.MappingNode:
+			merged = append(merged, valNode)
+		case yaml.SequenceNode:
+			for _, elemNode := range valNode.Content {
+				elemNode = resolveYamlAlias(elemNode)
+				if elemNode.Kind != yaml.MappingNode {
+					return nil, ErrYamlMergeNotMapping(keyNode.Line)
+				}
+				merged = append(merged, elemNode)
+			}
+		default:
+			return nil, ErrYamlMergeNotMapping(keyNode.Line)
+		}
+	}
+
+	for _, m := range merged {
+		mergedPairs, err := yamlMergedPairs(m)
+		if err != nil {
+			return nil, err
+		}
+		for _, pair := range mergedPairs {
+			key := normalizeKey(pair.key.Value)
+			if set[key] {
+				continue
+			}
+			set[key] = true
+			pairs = append(pairs, pair)
+		}
+	}
+	return pairs, nil
+}
+
+// yamlScalars returns the values of the elements of a sequence if they are all scalars
+func yamlScalars(seq *yaml.Node) ([]string, bool) {
+	elems := make([]string, len(seq.Content))
+	for i, elemNode := range seq.Content {
+		elemNode = resolveYamlAlias(elemNode)
+		if elemNode.Kind != yaml.ScalarNode {
+			return nil, false
+		}
+		elems[i] = elemNode.Value
+	}
+	return elems, true
+}
+
+// yamlScalarPairs returns the members of a mapping, merge keys applied, if their values are all scalars
+func yamlScalarPairs(mapping *yaml.Node) (map[string]string, bool) {
+	merged, err := yamlMergedPairs(mapping)
+	if err != nil {
+		return nil, false
+	}
+	pairs := make(map[string]string, len(merged))
+	for _, pair := range merged {
+		if pair.val.Kind != yaml.ScalarNode {
+			return nil, false
+		}
+		pairs[pair.key.Value] = pair.val.Value
+	}
+	return pairs, true
+}
+
+// yamlFlowString renders a sequence or mapping node on one line (e.g. "[a, b]" or "{a: 1, b: 2}")
+func yamlFlowString(node *yaml.Node) string {
+	flow := *node
+	flow.Style = yaml.FlowStyle
+	out, err := yaml.Marshal(&flow)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}